@@ -1,9 +1,13 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/SeerUK/tid/pkg/state"
 	"github.com/SeerUK/tid/pkg/state/bolt"
 	"github.com/SeerUK/tid/pkg/state/migrate"
 	_ "github.com/SeerUK/tid/pkg/state/migrate/versions"
@@ -12,28 +16,68 @@ import (
 	"github.com/SeerUK/tid/pkg/toml"
 	"github.com/SeerUK/tid/pkg/types"
 	"github.com/SeerUK/tid/pkg/util"
-	boltdb "github.com/boltdb/bolt"
+	"github.com/SeerUK/tid/pkg/xtime"
 )
 
 func main() {
-	dir, err := tid.GetLocalDirectory()
+	dataDir, args := parseDataDir(os.Args[1:])
+
+	dirs, err := tid.GetDirectories(dataDir)
 	fatal(err)
 
-	config := getTomlConfig(dir)
+	// Status checks made by shell prompts and status bars don't write anything, not even the config.
+	if isFastPath(args) {
+		config, err := toml.Read(dirs.Config)
+		fatal(err)
+
+		runReadOnly(getConnector(dirs, config), getClock(config), config, args, false)
+	}
+
+	config := getTomlConfig(dirs.Config)
+
+	clock := getClock(config)
+
+	connector := getConnector(dirs, config)
+
+	// Commands that only query data shouldn't block other tid processes by taking the write lock, and
+	// neither should commands while they wait on the user.
+	if cli.IsReadOnly(args) || cli.IsInteractive(args) {
+		runReadOnly(connector, clock, config, args, cli.IsInteractive(args) || cli.IsLongRunning(args))
+	}
 
-	db := getBoltDB(dir)
-	defer db.Close()
+	backend, err := connector.Open()
+	fatal(err)
 
-	backend := bolt.NewBoltBackend(db)
+	defer backend.Close()
 
 	// Initialise the backend, preparing it for use, ensuring it's up-to-date.
 	err = migrate.Backend(backend)
 	fatal(err)
 
-	factory := util.NewStandardFactory(backend)
-	kernel := cli.NewTidKernel(backend, factory, config)
+	factory := util.NewStandardFactory(backend, clock)
+
+	// Stop any timeboxed timer that has run past it's planned duration, before doing anything else.
+	// Undo and redo would otherwise act on the stop, rather than on what the user last did.
+	if !cli.IsJournal(args) {
+		stopExpiredTimer(factory)
+	}
+
+	// The write lock is already held, so commands that wait on the user just use it.
+	opener := func() (util.Factory, func() error, error) {
+		return factory, func() error { return nil }, nil
+	}
+
+	kernel := cli.NewTidKernel(backend, connector, factory, opener, config)
 
-	os.Exit(cli.CreateApplication(kernel).Run(os.Args[1:], os.Environ()))
+	os.Exit(cli.CreateApplication(kernel).Run(args, os.Environ()))
+}
+
+// getClock gets the Clock to get the current time from, in the time zone set in the config.
+func getClock(config types.Config) xtime.Clock {
+	clock, err := tid.GetClock(config.Tracking.Timezone.TimeLocation())
+	fatal(err)
+
+	return clock
 }
 
 // getTomlConfig gets the config from a TOML configuration file.
@@ -44,13 +88,130 @@ func getTomlConfig(dir string) types.Config {
 	return tomlConfig
 }
 
-// getBoltDB gets a Bolt DB instance.
-func getBoltDB(dir string) *boltdb.DB {
-	// Open Bolt database.
-	db, err := bolt.Open(dir)
+// getConnector gets a Connector for the database, which is either in the data directory, or at the
+// path set in the config.
+func getConnector(dirs tid.Directories, config types.Config) state.Connector {
+	path := filepath.Join(dirs.Data, bolt.BoltDatabaseFilename)
+
+	if config.Database.Path != "" {
+		expanded, err := tid.ExpandPath(config.Database.Path)
+		fatal(err)
+
+		path = expanded
+
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dirs.Config, path)
+		}
+	}
+
+	return bolt.NewBoltConnector(path, config.Database.Timeout())
+}
+
+// parseDataDir removes the data directory option from the given arguments, as it's needed before
+// the application can be created, returning it's value, and the remaining arguments.
+func parseDataDir(args []string) (string, []string) {
+	var dataDir string
+	var remaining []string
+
+	for i := 0; i < len(args); i++ {
+		switch {
+		case strings.HasPrefix(args[i], cli.DataDirOption+"="):
+			dataDir = strings.TrimPrefix(args[i], cli.DataDirOption+"=")
+		case args[i] == cli.DataDirOption && i+1 < len(args):
+			dataDir = args[i+1]
+			i++
+		default:
+			remaining = append(remaining, args[i])
+		}
+	}
+
+	return dataDir, remaining
+}
+
+// isFastPath returns true if the given arguments are for a command that is run often enough (e.g.
+// in a shell prompt) that it should avoid doing anything that writes, if it can.
+func isFastPath(args []string) bool {
+	if len(args) == 0 || (args[0] != "status" && args[0] != "st") {
+		return false
+	}
+
+	for _, arg := range args[1:] {
+		if arg == "-s" || arg == "--short" || arg == "--porcelain" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// runReadOnly runs the application using a read-only handle on the database, skipping migrations,
+// and exits. Quick queries (e.g. status) read straight from the database, which only blocks writers
+// for as long as they take. If snapshot is true, the database is instead copied into memory, and
+// only locked while the copy is taken, so that slow commands (e.g. large reports) and commands that
+// wait on the user don't block other tid processes; the latter open the database for writing once
+// they're done waiting. If the database can't be used like this (e.g. it doesn't exist yet, needs
+// migrating, or there's a timer that needs stopping) then this returns, and the application should
+// start up as usual.
+func runReadOnly(connector state.Connector, clock xtime.Clock, config types.Config, args []string, snapshot bool) {
+	open := connector.OpenReadOnly
+	if snapshot {
+		open = connector.OpenSnapshot
+	}
+
+	backend, err := open()
+	if err == state.ErrBackendBusy {
+		fatal(err)
+	}
+
+	if err != nil {
+		return
+	}
+
+	if !migrate.IsUpToDate(backend) {
+		backend.Close()
+		return
+	}
+
+	factory := util.NewStandardFactory(backend, clock)
+
+	_, expired, err := factory.BuildTrackingFacade().FindExpired()
+	if err != nil || (expired && !cli.IsJournal(args)) {
+		backend.Close()
+		return
+	}
+
+	opener := func() (util.Factory, func() error, error) {
+		writable, err := connector.Open()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return util.NewStandardFactory(writable, clock), writable.Close, nil
+	}
+
+	kernel := cli.NewTidKernel(backend, connector, factory, opener, config)
+
+	code := cli.CreateApplication(kernel).Run(args, os.Environ())
+
+	backend.Close()
+	os.Exit(code)
+}
+
+// stopExpiredTimer stops the running timer if it has been tracked for longer than planned, letting
+// the user know that it has been stopped.
+func stopExpiredTimer(factory util.Factory) {
+	entry, stopped, err := factory.BuildTrackingFacade().StopExpired()
 	fatal(err)
 
-	return db
+	if stopped {
+		fmt.Fprintf(
+			os.Stderr,
+			"Timebox for '%s' (%s) ended, timer stopped at %s\n",
+			entry.Note,
+			entry.ShortHash(),
+			entry.Updated.Format(entry.UpdatedTimeFormat()),
+		)
+	}
 }
 
 // fatal kills the application upon error.
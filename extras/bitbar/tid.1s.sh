@@ -18,25 +18,23 @@
 # is located if `which tid` provides no results
 TID=$(which tid || echo "/usr/local/bin/tid")
 
-# keep 'note' at end of this string so we can be lazy and slice to the end of the array :P
-# without having to deal with spaces
-status=`$TID status -f="{{.ShortHash}} {{.Duration}} {{.IsRunning}} {{.Note}}"`
+status=`$TID status --porcelain`
+code=$?
 
-if [ $? -ne 0 ]; then
+# 0 is running, and 3 is stopped. Anything else means there's no timer, or tid failed.
+if [ $code -ne 0 ] && [ $code -ne 3 ]; then
 	echo "--:--"
 	echo "---"
 	echo "No timer running"
 	exit
 fi
 
-arr=($status)
+# The note is the last field, so it's read into the last variable, spaces and all.
+read -r state hash seconds timesheet note <<< "$status"
 
-hash=${arr[0]}
-duration=${arr[1]}
-isRunning=${arr[2]}
-note=${arr[@]:3}
+duration=$(printf "%d:%02d" $((seconds / 3600)) $((seconds % 3600 / 60)))
 
-if [ "$isRunning" == "true" ]; then
+if [ "$state" == "running" ]; then
 	echo "$duration | color=green"
 	echo "---"
 	echo "$note ($hash)"
@@ -0,0 +1,171 @@
+package ics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/types"
+)
+
+// icsDateFmt is the date format used by iCalendar DATE values, and the date part of DATE-TIME
+// values.
+const icsDateFmt = "20060102"
+
+// event represents the properties of a VEVENT that we care about.
+type event struct {
+	allDay  bool
+	start   string
+	end     string
+	summary string
+}
+
+// ParseDays parses the all-day events in an iCalendar (.ics) file as non-working days. Events
+// spanning multiple days produce a day for each date they cover. Timed events (e.g. meetings) are
+// ignored, as they don't take up the whole day.
+func ParseDays(reader io.Reader) ([]types.CalendarDay, error) {
+	var days []types.CalendarDay
+	var current *event
+
+	lines, err := unfoldLines(reader)
+	if err != nil {
+		return days, err
+	}
+
+	for _, line := range lines {
+		name, params, value := splitProperty(line)
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &event{}
+		case name == "END" && value == "VEVENT" && current != nil:
+			if !current.allDay {
+				current = nil
+				continue
+			}
+
+			eventDays, err := expandEvent(*current)
+			if err != nil {
+				return days, err
+			}
+
+			days = append(days, eventDays...)
+			current = nil
+		case current == nil:
+			continue
+		case name == "DTSTART":
+			current.start = value
+			current.allDay = isDate(params, value)
+		case name == "DTEND":
+			current.end = value
+		case name == "SUMMARY":
+			current.summary = unescapeText(value)
+		}
+	}
+
+	return days, nil
+}
+
+// expandEvent creates a day for each date the given all-day event covers.
+func expandEvent(ev event) ([]types.CalendarDay, error) {
+	var days []types.CalendarDay
+
+	start, err := parseDate(ev.start)
+	if err != nil {
+		return days, err
+	}
+
+	end := start
+
+	if ev.end != "" {
+		end, err = parseDate(ev.end)
+		if err != nil {
+			return days, err
+		}
+
+		// The end of an all-day event is exclusive.
+		if end.After(start) {
+			end = end.AddDate(0, 0, -1)
+		}
+	}
+
+	for current := start; !current.After(end); current = current.AddDate(0, 0, 1) {
+		days = append(days, types.CalendarDay{
+			Date:   current.Format(types.TimesheetKeyDateFmt),
+			Reason: ev.summary,
+		})
+	}
+
+	return days, nil
+}
+
+// parseDate parses the date part of an iCalendar DATE or DATE-TIME value.
+func parseDate(value string) (time.Time, error) {
+	if len(value) < len(icsDateFmt) {
+		return time.Time{}, fmt.Errorf("ics: Invalid date '%s'", value)
+	}
+
+	date, err := time.Parse(icsDateFmt, value[:len(icsDateFmt)])
+	if err != nil {
+		return date, fmt.Errorf("ics: Invalid date '%s'", value)
+	}
+
+	return date, nil
+}
+
+// isDate returns true if a property with the given parameters and value is a DATE, rather than a
+// DATE-TIME, i.e. it's either marked with VALUE=DATE, or has no time part.
+func isDate(params string, value string) bool {
+	for _, param := range strings.Split(strings.ToUpper(params), ";") {
+		if param == "VALUE=DATE" {
+			return true
+		}
+	}
+
+	return len(value) == len(icsDateFmt) && !strings.Contains(value, "T")
+}
+
+// splitProperty splits a content line into it's property name, parameters, and value.
+func splitProperty(line string) (string, string, string) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return strings.ToUpper(line), "", ""
+	}
+
+	name := line[:colon]
+	params := ""
+
+	if semicolon := strings.Index(name, ";"); semicolon >= 0 {
+		name, params = name[:semicolon], name[semicolon+1:]
+	}
+
+	return strings.ToUpper(name), params, line[colon+1:]
+}
+
+// unfoldLines reads all content lines, joining any lines that have been folded over multiple
+// lines back together.
+func unfoldLines(reader io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] = lines[len(lines)-1] + line[1:]
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// unescapeText unescapes an iCalendar TEXT value.
+func unescapeText(text string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(text)
+}
@@ -0,0 +1,65 @@
+package ics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SeerUK/tid/pkg/ics"
+	"github.com/SeerUK/tid/pkg/types"
+)
+
+func TestParseDays(t *testing.T) {
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20171225",
+		"DTEND;VALUE=DATE:20171227",
+		"SUMMARY:Christmas\\, and Boxing Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20170301T090000Z",
+		"DTEND:20170301T100000Z",
+		"SUMMARY:Stand-up",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;TZID=Europe/London:20170302T000000",
+		"DTEND;TZID=Europe/London:20170303T000000",
+		"SUMMARY:All night meeting",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20170417",
+		"SUMMARY:Easter Monday, a very long summary that has been",
+		"  folded over two lines",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	days, err := ics.ParseDays(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error parsing days: %v", err)
+	}
+
+	expected := []types.CalendarDay{
+		{Date: "2017-12-25", Reason: "Christmas, and Boxing Day"},
+		{Date: "2017-12-26", Reason: "Christmas, and Boxing Day"},
+		{Date: "2017-04-17", Reason: "Easter Monday, a very long summary that has been folded over two lines"},
+	}
+
+	if len(days) != len(expected) {
+		t.Fatalf("expected %d days, got: %+v", len(expected), days)
+	}
+
+	for i, day := range days {
+		if day != expected[i] {
+			t.Errorf("expected day %d to be %+v, got %+v", i, expected[i], day)
+		}
+	}
+}
+
+func TestParseDaysInvalidDate(t *testing.T) {
+	data := "BEGIN:VEVENT\nDTSTART;VALUE=DATE:2017\nEND:VEVENT\n"
+
+	if _, err := ics.ParseDays(strings.NewReader(data)); err == nil {
+		t.Error("expected an error parsing an invalid date")
+	}
+}
@@ -14,8 +14,13 @@ const (
 	BackendBucketWorkspaceFmt = "tid_tracking_%s"
 )
 
-// ErrNilBucket is the error given when there is no entry found for a key in the database.
-var ErrNilBucket = errors.New("state: No bucket found")
+var (
+	// ErrNilBucket is the error given when there is no entry found for a key in the database.
+	ErrNilBucket = errors.New("state: No bucket found")
+	// ErrBackendBusy is the error given when the database is being used by another process for too
+	// long to wait for it.
+	ErrBackendBusy = errors.New("state: The database is busy, another tid command is using it")
+)
 
 // Backend provides an abstraction over underlying backend database technologies. It is separate to
 // Store because Store is a more specialised interface with less functionality. Backend does not
@@ -39,4 +44,27 @@ type Backend interface {
 	// ForEachSingle loops over each key/value pair individually in the given bucket. As buckets can
 	// contain different data types we resort to using byte arrays for values.
 	ForEachSingle(bucket string, fn func(key string, val []byte) error) error
+
+	// -- Transactions
+	// Update runs the given function with a Backend that makes every change the function makes
+	// together, as one transaction. If the function returns an error, none of it's changes are made.
+	Update(fn func(backend Backend) error) error
+
+	// -- Connection
+	// Close releases the underlying database. The Backend must not be used once closed.
+	Close() error
+}
+
+// Connector opens Backend instances, i.e. connections to the underlying database.
+type Connector interface {
+	// Open opens a writable Backend. Only one writable Backend may be open at a time, across all tid
+	// processes.
+	Open() (Backend, error)
+	// OpenReadOnly opens a read-only Backend. Read-only Backends may be open alongside each other,
+	// but not alongside a writable Backend, so they should be closed as soon as possible.
+	OpenReadOnly() (Backend, error)
+	// OpenSnapshot opens a Backend holding a copy of the database in memory. The database itself
+	// is only used while it's being copied, so a snapshot can be used for as long as needed without
+	// blocking other tid processes. Changes made to a snapshot are not saved.
+	OpenSnapshot() (Backend, error)
 }
@@ -63,7 +63,10 @@ func (b *boltBackend) Read(bucket string, key string) ([]byte, error) {
 			return state.ErrNilBucket
 		}
 
-		value = bucket.Get([]byte(key))
+		// Values are only valid for the life of the transaction, so they must be copied.
+		if v := bucket.Get([]byte(key)); v != nil {
+			value = append([]byte{}, v...)
+		}
 
 		return nil
 	})
@@ -140,3 +143,119 @@ func (b *boltBackend) ForEachSingle(bucket string, fn func(key string, val []byt
 
 	return nil
 }
+
+func (b *boltBackend) Update(fn func(backend state.Backend) error) error {
+	return b.db.Update(func(tx *boltdb.Tx) error {
+		return fn(&boltTxBackend{tx: tx})
+	})
+}
+
+func (b *boltBackend) Close() error {
+	return b.db.Close()
+}
+
+// boltTxBackend implements the Backend interface over a single, writable, Bolt DB transaction. It's
+// used to make several changes together, and is only valid until the transaction ends.
+type boltTxBackend struct {
+	tx *boltdb.Tx
+}
+
+func (b *boltTxBackend) CreateBucketIfNotExists(name string) error {
+	_, err := b.tx.CreateBucketIfNotExists([]byte(name))
+
+	return err
+}
+
+func (b *boltTxBackend) HasBucket(name string) bool {
+	return b.tx.Bucket([]byte(name)) != nil
+}
+
+func (b *boltTxBackend) DeleteBucket(name string) error {
+	err := b.tx.DeleteBucket([]byte(name))
+
+	if err == boltdb.ErrBucketNotFound {
+		return state.ErrNilBucket
+	}
+
+	return err
+}
+
+func (b *boltTxBackend) Read(bucket string, key string) ([]byte, error) {
+	values := b.tx.Bucket([]byte(bucket))
+	if values == nil {
+		return nil, state.ErrNilBucket
+	}
+
+	// Values are only valid for the life of the transaction, so they must be copied.
+	value := values.Get([]byte(key))
+	if value == nil {
+		return nil, state.ErrStoreNilResult
+	}
+
+	return append([]byte{}, value...), nil
+}
+
+func (b *boltTxBackend) Write(bucket string, key string, value []byte) error {
+	values := b.tx.Bucket([]byte(bucket))
+	if values == nil {
+		return state.ErrNilBucket
+	}
+
+	return values.Put([]byte(key), value)
+}
+
+func (b *boltTxBackend) Delete(bucket string, key string) error {
+	values := b.tx.Bucket([]byte(bucket))
+	if values == nil {
+		return state.ErrNilBucket
+	}
+
+	return values.Delete([]byte(key))
+}
+
+func (b *boltTxBackend) ForEachSingle(bucket string, fn func(key string, val []byte) error) error {
+	values := b.tx.Bucket([]byte(bucket))
+	if values == nil {
+		return state.ErrNilBucket
+	}
+
+	// Bolt doesn't allow a bucket to be changed while it's being iterated over, so the keys are
+	// collected first, leaving the user-defined function free to use the backend too.
+	var keys []string
+
+	values.ForEach(func(key []byte, val []byte) error {
+		if val != nil {
+			keys = append(keys, string(key))
+		}
+
+		return nil
+	})
+
+	for _, key := range keys {
+		value, err := b.Read(bucket, key)
+		if err == state.ErrStoreNilResult {
+			// The key was deleted by an earlier call to the user-defined function.
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *boltTxBackend) Update(fn func(backend state.Backend) error) error {
+	// Bolt transactions can't be nested, so changes are simply made as part of this one.
+	return fn(b)
+}
+
+func (b *boltTxBackend) Close() error {
+	// The transaction is ended by the boltBackend that started it.
+	return nil
+}
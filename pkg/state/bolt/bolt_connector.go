@@ -0,0 +1,79 @@
+package bolt
+
+import (
+	"time"
+
+	"github.com/SeerUK/tid/pkg/state"
+	"github.com/SeerUK/tid/pkg/state/memory"
+
+	boltdb "github.com/boltdb/bolt"
+)
+
+// boltConnector implements the Connector interface to open Bolt DB backed Backends.
+type boltConnector struct {
+	// path is the path to the database file.
+	path string
+	// timeout is how long to wait for other processes to release the database.
+	timeout time.Duration
+}
+
+// NewBoltConnector creates a new Connector instance that opens the Bolt database at the given path,
+// waiting up to the given timeout for other processes to release it.
+func NewBoltConnector(path string, timeout time.Duration) state.Connector {
+	return &boltConnector{
+		path:    path,
+		timeout: timeout,
+	}
+}
+
+func (c *boltConnector) Open() (state.Backend, error) {
+	db, err := Open(c.path, c.timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBoltBackend(db), nil
+}
+
+func (c *boltConnector) OpenReadOnly() (state.Backend, error) {
+	db, err := OpenReadOnly(c.path, c.timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBoltBackend(db), nil
+}
+
+func (c *boltConnector) OpenSnapshot() (state.Backend, error) {
+	db, err := OpenReadOnly(c.path, c.timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	snapshot := memory.NewMemoryBackend()
+
+	err = db.View(func(tx *boltdb.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *boltdb.Bucket) error {
+			if err := snapshot.CreateBucketIfNotExists(string(name)); err != nil {
+				return err
+			}
+
+			return bucket.ForEach(func(key []byte, val []byte) error {
+				// Nested buckets have no value, and aren't used by tid.
+				if val == nil {
+					return nil
+				}
+
+				return snapshot.Write(string(name), string(key), val)
+			})
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
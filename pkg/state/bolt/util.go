@@ -1,8 +1,11 @@
 package bolt
 
 import (
-	"fmt"
 	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/state"
 
 	boltdb "github.com/boltdb/bolt"
 )
@@ -10,13 +13,40 @@ import (
 // BoltDatabaseFilename is the name of the database file name on disk.
 const BoltDatabaseFilename = "tid.db"
 
-// Open opens a Bolt database, creating it if it doesn't exist already.
-func Open(tidDir string) (*boltdb.DB, error) {
-	// Make the `path` if it does not exist.
-	err := os.MkdirAll(tidDir, os.ModePerm)
+// Open opens the Bolt database at the given path, creating it if it doesn't exist already. If
+// another process is using the database for longer than the given timeout, state.ErrBackendBusy is
+// returned.
+func Open(path string, timeout time.Duration) (*boltdb.DB, error) {
+	// Make the directory containing the `path` if it does not exist.
+	err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
 	if err != nil {
 		return nil, err
 	}
 
-	return boltdb.Open(fmt.Sprintf("%s/%s", tidDir, BoltDatabaseFilename), 0600, nil)
+	db, err := boltdb.Open(path, 0600, &boltdb.Options{
+		Timeout: timeout,
+	})
+
+	return db, translateError(err)
+}
+
+// OpenReadOnly opens the existing Bolt database at the given path in read-only mode. If another
+// process is writing to the database for longer than the given timeout, state.ErrBackendBusy is
+// returned.
+func OpenReadOnly(path string, timeout time.Duration) (*boltdb.DB, error) {
+	db, err := boltdb.Open(path, 0600, &boltdb.Options{
+		ReadOnly: true,
+		Timeout:  timeout,
+	})
+
+	return db, translateError(err)
+}
+
+// translateError converts Bolt-specific errors into their state package equivalents.
+func translateError(err error) error {
+	if err == boltdb.ErrTimeout {
+		return state.ErrBackendBusy
+	}
+
+	return err
 }
@@ -0,0 +1,265 @@
+package state
+
+import (
+	"github.com/SeerUK/tid/pkg/errhandling"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+// JournalSize is the maximum number of operations kept in the journal. Older operations are
+// forgotten, and can no longer be undone.
+const JournalSize = 100
+
+// Journal records the changes made by each operation, so that they can be undone and redone.
+type Journal interface {
+	// Begin starts recording an operation with the given description. Operations may be nested, in
+	// which case the changes made by nested operations are recorded as part of the outermost one.
+	Begin(description string)
+	// End stops recording the current operation. When the outermost operation ends, it's saved to
+	// the journal, if it changed anything.
+	End() error
+}
+
+// JournalBackend is a Backend that records the changes made to another Backend while an operation
+// is being recorded, as a Journal. Changes made outside of an operation are not recorded.
+type JournalBackend struct {
+	// backend is the Backend that changes are made to.
+	backend Backend
+	// clock is a Clock used to get the current time.
+	clock xtime.Clock
+	// depth is how many operations are being recorded, including nested operations.
+	depth int
+	// operation is the operation being recorded, or nil if no operation is being recorded.
+	operation *types.JournalOperation
+	// changes maps buckets and keys to the index of their change in the operation being recorded.
+	changes map[string]int
+}
+
+// NewJournalBackend creates a new JournalBackend instance, recording changes made to the given
+// Backend.
+func NewJournalBackend(backend Backend, clock xtime.Clock) *JournalBackend {
+	return &JournalBackend{
+		backend: backend,
+		clock:   clock,
+	}
+}
+
+// Begin starts recording an operation with the given description.
+func (b *JournalBackend) Begin(description string) {
+	b.depth++
+
+	if b.depth > 1 {
+		return
+	}
+
+	operation := types.NewJournalOperation(description, b.clock.Now())
+
+	b.operation = &operation
+	b.changes = make(map[string]int)
+}
+
+// End stops recording the current operation. When the outermost operation ends, it's added to the
+// journal if it changed anything. Adding an operation forgets any operations that had been undone,
+// and the oldest operations if the journal is full.
+func (b *JournalBackend) End() error {
+	if b.depth == 0 {
+		return nil
+	}
+
+	b.depth--
+
+	if b.depth > 0 {
+		return nil
+	}
+
+	operation := b.operation
+
+	b.operation = nil
+	b.changes = nil
+
+	if len(operation.Changes) == 0 {
+		return nil
+	}
+
+	return b.backend.Update(func(backend Backend) error {
+		sysGateway := NewStoreSysGateway(NewBackendStore(backend, BackendBucketSys))
+
+		journal, err := sysGateway.FindOrCreateJournal()
+		if err != nil {
+			return err
+		}
+
+		forgotten := journal.Push(operation.Key)
+		forgotten = append(forgotten, journal.Trim(JournalSize)...)
+
+		errs := errhandling.NewErrorStack()
+		errs.Add(sysGateway.PersistJournalOperation(*operation))
+		errs.Add(sysGateway.PersistJournal(journal))
+
+		for _, key := range forgotten {
+			errs.Add(sysGateway.DeleteJournalOperation(key))
+		}
+
+		return errs.Errors()
+	})
+}
+
+// CreateBucketIfNotExists attempts to create a bucket with a given name, if it doesn't exist.
+func (b *JournalBackend) CreateBucketIfNotExists(name string) error {
+	if b.operation == nil || b.backend.HasBucket(name) {
+		return b.backend.CreateBucketIfNotExists(name)
+	}
+
+	if err := b.backend.CreateBucketIfNotExists(name); err != nil {
+		return err
+	}
+
+	b.change(name, "").Created = true
+
+	return nil
+}
+
+// HasBucket returns true if the underlying Backend has a bucket with the given name.
+func (b *JournalBackend) HasBucket(name string) bool {
+	return b.backend.HasBucket(name)
+}
+
+// DeleteBucket attempts to remove a bucket, recording each of the keys in it as deleted.
+func (b *JournalBackend) DeleteBucket(name string) error {
+	if b.operation == nil || !b.backend.HasBucket(name) {
+		return b.backend.DeleteBucket(name)
+	}
+
+	err := b.backend.ForEachSingle(name, func(key string, val []byte) error {
+		if err := b.capture(name, key); err != nil {
+			return err
+		}
+
+		change := b.change(name, key)
+		change.After = nil
+		change.Deleted = true
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if err := b.backend.DeleteBucket(name); err != nil {
+		return err
+	}
+
+	b.change(name, "").Deleted = true
+
+	return nil
+}
+
+// Read a value with a given key from a given bucket.
+func (b *JournalBackend) Read(bucket string, key string) ([]byte, error) {
+	return b.backend.Read(bucket, key)
+}
+
+// Write a given value to a key in a given bucket, recording the change.
+func (b *JournalBackend) Write(bucket string, key string, val []byte) error {
+	if err := b.capture(bucket, key); err != nil {
+		return err
+	}
+
+	if err := b.backend.Write(bucket, key, val); err != nil {
+		return err
+	}
+
+	if b.operation == nil {
+		return nil
+	}
+
+	change := b.change(bucket, key)
+	change.After = append([]byte{}, val...)
+	change.Deleted = false
+
+	return nil
+}
+
+// Delete a value with a given key from a given bucket, recording the change.
+func (b *JournalBackend) Delete(bucket string, key string) error {
+	if err := b.capture(bucket, key); err != nil {
+		return err
+	}
+
+	if err := b.backend.Delete(bucket, key); err != nil {
+		return err
+	}
+
+	if b.operation == nil {
+		return nil
+	}
+
+	change := b.change(bucket, key)
+	change.After = nil
+	change.Deleted = true
+
+	return nil
+}
+
+// ForEachSingle loops over each key/value pair individually in the given bucket.
+func (b *JournalBackend) ForEachSingle(bucket string, fn func(key string, val []byte) error) error {
+	return b.backend.ForEachSingle(bucket, fn)
+}
+
+// Update runs the given function with a Backend that makes every change the function makes
+// together, as one transaction. While an operation is being recorded, the function is given this
+// JournalBackend instead, so that it's changes are recorded, but they're not made in a transaction.
+func (b *JournalBackend) Update(fn func(backend Backend) error) error {
+	if b.operation != nil {
+		return fn(b)
+	}
+
+	return b.backend.Update(fn)
+}
+
+// Close releases the underlying Backend.
+func (b *JournalBackend) Close() error {
+	return b.backend.Close()
+}
+
+// capture records the value of the given key before the operation being recorded first changes
+// it. Nothing is recorded if no operation is being recorded, or the key has already been changed.
+func (b *JournalBackend) capture(bucket string, key string) error {
+	if b.operation == nil {
+		return nil
+	}
+
+	if _, ok := b.changes[bucket+"\x00"+key]; ok {
+		return nil
+	}
+
+	before, err := b.backend.Read(bucket, key)
+	if err != nil && err != ErrStoreNilResult && err != ErrNilBucket {
+		return err
+	}
+
+	change := b.change(bucket, key)
+	change.Before = before
+	change.Created = err != nil
+
+	return nil
+}
+
+// change returns the change to the given bucket and key in the operation being recorded, adding one
+// if it hasn't been changed yet. An empty key refers to the bucket itself.
+func (b *JournalBackend) change(bucket string, key string) *types.JournalChange {
+	index, ok := b.changes[bucket+"\x00"+key]
+	if !ok {
+		index = len(b.operation.Changes)
+
+		b.operation.Changes = append(b.operation.Changes, types.JournalChange{
+			Bucket: bucket,
+			Key:    key,
+		})
+
+		b.changes[bucket+"\x00"+key] = index
+	}
+
+	return &b.operation.Changes[index]
+}
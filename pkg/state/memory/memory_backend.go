@@ -0,0 +1,186 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/SeerUK/tid/pkg/state"
+)
+
+// memoryBackend implements the Backend interface to provide a simple key / value store that only
+// lives as long as the process does. It's useful for testing, and for embedding tid.
+type memoryBackend struct {
+	sync.RWMutex
+
+	// buckets maps bucket names to the key / value pairs within them.
+	buckets map[string]map[string][]byte
+}
+
+// NewMemoryBackend creates a new, empty, in-memory Backend instance.
+func NewMemoryBackend() state.Backend {
+	return &memoryBackend{
+		buckets: make(map[string]map[string][]byte),
+	}
+}
+
+func (b *memoryBackend) CreateBucketIfNotExists(name string) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if _, ok := b.buckets[name]; !ok {
+		b.buckets[name] = make(map[string][]byte)
+	}
+
+	return nil
+}
+
+func (b *memoryBackend) HasBucket(name string) bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	_, ok := b.buckets[name]
+
+	return ok
+}
+
+func (b *memoryBackend) DeleteBucket(name string) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if _, ok := b.buckets[name]; !ok {
+		return state.ErrNilBucket
+	}
+
+	delete(b.buckets, name)
+
+	return nil
+}
+
+func (b *memoryBackend) Read(bucket string, key string) ([]byte, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	values, ok := b.buckets[bucket]
+	if !ok {
+		return nil, state.ErrNilBucket
+	}
+
+	value, ok := values[key]
+	if !ok {
+		return nil, state.ErrStoreNilResult
+	}
+
+	return copyBytes(value), nil
+}
+
+func (b *memoryBackend) Write(bucket string, key string, value []byte) error {
+	b.Lock()
+	defer b.Unlock()
+
+	values, ok := b.buckets[bucket]
+	if !ok {
+		return state.ErrNilBucket
+	}
+
+	values[key] = copyBytes(value)
+
+	return nil
+}
+
+func (b *memoryBackend) Delete(bucket string, key string) error {
+	b.Lock()
+	defer b.Unlock()
+
+	values, ok := b.buckets[bucket]
+	if !ok {
+		return state.ErrNilBucket
+	}
+
+	delete(values, key)
+
+	return nil
+}
+
+func (b *memoryBackend) ForEachSingle(bucket string, fn func(key string, val []byte) error) error {
+	// Like the Bolt backend, keys are visited in byte-sorted order, and no lock is held while the
+	// user-defined function runs, so it's free to use the backend too.
+	b.RLock()
+
+	values, ok := b.buckets[bucket]
+	if !ok {
+		b.RUnlock()
+		return state.ErrNilBucket
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	b.RUnlock()
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, err := b.Read(bucket, key)
+		if err == state.ErrStoreNilResult {
+			// The key was deleted by an earlier call to the user-defined function.
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = fn(key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *memoryBackend) Update(fn func(backend state.Backend) error) error {
+	// No lock is held while the user-defined function runs, so it's free to use the backend. If it
+	// fails, everything is put back how it was before it ran.
+	b.RLock()
+
+	before := make(map[string]map[string][]byte, len(b.buckets))
+	for name, values := range b.buckets {
+		before[name] = make(map[string][]byte, len(values))
+
+		for key, value := range values {
+			before[name][key] = value
+		}
+	}
+
+	b.RUnlock()
+
+	if err := fn(b); err != nil {
+		b.Lock()
+		b.buckets = before
+		b.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+func (b *memoryBackend) Close() error {
+	return nil
+}
+
+// copyBytes returns a copy of the given bytes, so that values in the store can't be modified by
+// callers after they've been written, or read.
+func copyBytes(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+
+	result := make([]byte, len(value))
+	copy(result, value)
+
+	return result
+}
@@ -0,0 +1,189 @@
+package memory
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SeerUK/tid/pkg/state"
+)
+
+func TestMemoryBackendBuckets(t *testing.T) {
+	backend := NewMemoryBackend()
+
+	if backend.HasBucket("test") {
+		t.Fatal("expected bucket not to exist before it's created")
+	}
+
+	if err := backend.CreateBucketIfNotExists("test"); err != nil {
+		t.Fatalf("unexpected error creating bucket: %v", err)
+	}
+
+	if !backend.HasBucket("test") {
+		t.Fatal("expected bucket to exist after it's created")
+	}
+
+	backend.Write("test", "key", []byte("value"))
+
+	// Creating an existing bucket should leave it's contents alone.
+	if err := backend.CreateBucketIfNotExists("test"); err != nil {
+		t.Fatalf("unexpected error creating existing bucket: %v", err)
+	}
+
+	if _, err := backend.Read("test", "key"); err != nil {
+		t.Fatalf("expected existing bucket contents to be kept, got: %v", err)
+	}
+
+	if err := backend.DeleteBucket("test"); err != nil {
+		t.Fatalf("unexpected error deleting bucket: %v", err)
+	}
+
+	if backend.HasBucket("test") {
+		t.Fatal("expected bucket not to exist after it's deleted")
+	}
+
+	if err := backend.DeleteBucket("test"); err != state.ErrNilBucket {
+		t.Fatalf("expected ErrNilBucket deleting a missing bucket, got: %v", err)
+	}
+}
+
+func TestMemoryBackendReadWriteDelete(t *testing.T) {
+	backend := NewMemoryBackend()
+	backend.CreateBucketIfNotExists("test")
+
+	if _, err := backend.Read("missing", "key"); err != state.ErrNilBucket {
+		t.Errorf("expected ErrNilBucket reading from a missing bucket, got: %v", err)
+	}
+
+	if err := backend.Write("missing", "key", []byte("value")); err != state.ErrNilBucket {
+		t.Errorf("expected ErrNilBucket writing to a missing bucket, got: %v", err)
+	}
+
+	if err := backend.Delete("missing", "key"); err != state.ErrNilBucket {
+		t.Errorf("expected ErrNilBucket deleting from a missing bucket, got: %v", err)
+	}
+
+	if _, err := backend.Read("test", "key"); err != state.ErrStoreNilResult {
+		t.Errorf("expected ErrStoreNilResult reading a missing key, got: %v", err)
+	}
+
+	value := []byte("value")
+
+	if err := backend.Write("test", "key", value); err != nil {
+		t.Fatalf("unexpected error writing: %v", err)
+	}
+
+	// Modifying the written value, or a read value, must not modify the stored value.
+	value[0] = 'X'
+
+	read, err := backend.Read("test", "key")
+	if err != nil {
+		t.Fatalf("unexpected error reading: %v", err)
+	}
+
+	read[1] = 'X'
+
+	read, _ = backend.Read("test", "key")
+	if string(read) != "value" {
+		t.Errorf("expected stored value to be %q, got %q", "value", read)
+	}
+
+	if err := backend.Delete("test", "key"); err != nil {
+		t.Fatalf("unexpected error deleting: %v", err)
+	}
+
+	if _, err := backend.Read("test", "key"); err != state.ErrStoreNilResult {
+		t.Errorf("expected ErrStoreNilResult reading a deleted key, got: %v", err)
+	}
+
+	if err := backend.Delete("test", "key"); err != nil {
+		t.Errorf("expected no error deleting a missing key, got: %v", err)
+	}
+}
+
+func TestMemoryBackendForEachSingle(t *testing.T) {
+	backend := NewMemoryBackend()
+	backend.CreateBucketIfNotExists("test")
+
+	for _, key := range []string{"c", "a", "d", "b"} {
+		backend.Write("test", key, []byte(key+key))
+	}
+
+	var keys []string
+	var values []string
+
+	err := backend.ForEachSingle("test", func(key string, val []byte) error {
+		keys = append(keys, key)
+		values = append(values, string(val))
+
+		// The backend should be usable from within the callback.
+		return backend.Delete("test", "c")
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := []string{"a", "b", "d"}; !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected keys %v, got %v", expected, keys)
+	}
+
+	if expected := []string{"aa", "bb", "dd"}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected values %v, got %v", expected, values)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+
+	err = backend.ForEachSingle("test", func(key string, val []byte) error {
+		calls++
+		return stop
+	})
+
+	if err != stop || calls != 1 {
+		t.Errorf("expected iteration to stop at the first error, got %v after %d calls", err, calls)
+	}
+
+	if err := backend.ForEachSingle("missing", nil); err != state.ErrNilBucket {
+		t.Errorf("expected ErrNilBucket iterating a missing bucket, got: %v", err)
+	}
+}
+
+func TestMemoryBackendUpdate(t *testing.T) {
+	backend := NewMemoryBackend()
+	backend.CreateBucketIfNotExists("test")
+	backend.Write("test", "key", []byte("before"))
+
+	fail := errors.New("fail")
+
+	err := backend.Update(func(backend state.Backend) error {
+		backend.Write("test", "key", []byte("after"))
+		backend.CreateBucketIfNotExists("other")
+
+		return fail
+	})
+
+	if err != fail {
+		t.Fatalf("expected the function's error, got: %v", err)
+	}
+
+	if value, _ := backend.Read("test", "key"); string(value) != "before" {
+		t.Errorf("expected changes to be put back after an error, got %q", value)
+	}
+
+	if backend.HasBucket("other") {
+		t.Error("expected created buckets to be removed after an error")
+	}
+
+	err = backend.Update(func(backend state.Backend) error {
+		return backend.Write("test", "key", []byte("after"))
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if value, _ := backend.Read("test", "key"); string(value) != "after" {
+		t.Errorf("expected changes to be kept, got %q", value)
+	}
+}
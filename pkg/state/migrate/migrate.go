@@ -39,6 +39,42 @@ func Backend(backend state.Backend) error {
 	status := types.NewMigrationsStatus()
 	status.FromMessage(&message)
 
+	missingVersions := pendingMigrations(status)
+
+	for _, migration := range missingVersions {
+		err := migration.Migrate(backend)
+		if err != nil {
+			return err
+		}
+
+		status.Versions = append(status.Versions, migration.Version())
+	}
+
+	store.Write(state.KeyMigrations, status.ToMessage())
+
+	return nil
+}
+
+// IsUpToDate returns true if the given state.Backend has had all migrations applied to it. Unlike
+// Backend, this never writes to the state.Backend, so it's safe to use with read-only backends.
+func IsUpToDate(backend state.Backend) bool {
+	if !backend.HasBucket(state.BackendBucketSys) {
+		return false
+	}
+
+	message := proto.SysMigrationsStatus{}
+
+	store := state.NewBackendStore(backend, state.BackendBucketSys)
+	store.Read(state.KeyMigrations, &message)
+
+	status := types.NewMigrationsStatus()
+	status.FromMessage(&message)
+
+	return len(pendingMigrations(status)) == 0
+}
+
+// pendingMigrations returns the registered migrations that have not been applied yet, in order.
+func pendingMigrations(status types.MigrationsStatus) []Migration {
 	sort.Slice(migrations, func(i, j int) bool {
 		return migrations[i].Version() < migrations[j].Version()
 	})
@@ -54,18 +90,7 @@ func Backend(backend state.Backend) error {
 		missingVersions = migrations[index+1:]
 	}
 
-	for _, migration := range missingVersions {
-		err := migration.Migrate(backend)
-		if err != nil {
-			return err
-		}
-
-		status.Versions = append(status.Versions, migration.Version())
-	}
-
-	store.Write(state.KeyMigrations, status.ToMessage())
-
-	return nil
+	return missingVersions
 }
 
 // indexOf uses the callback to find the index for some value.
@@ -0,0 +1,143 @@
+package migrate_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/state"
+	"github.com/SeerUK/tid/pkg/state/memory"
+	"github.com/SeerUK/tid/pkg/state/migrate"
+	_ "github.com/SeerUK/tid/pkg/state/migrate/versions"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/proto"
+)
+
+func TestBackendFresh(t *testing.T) {
+	backend := memory.NewMemoryBackend()
+
+	if migrate.IsUpToDate(backend) {
+		t.Fatal("expected a fresh backend not to be up-to-date")
+	}
+
+	if err := migrate.Backend(backend); err != nil {
+		t.Fatalf("unexpected error migrating: %v", err)
+	}
+
+	if !migrate.IsUpToDate(backend) {
+		t.Fatal("expected a migrated backend to be up-to-date")
+	}
+
+	if backend.HasBucket(state.BackendBucketTimesheet) {
+		t.Error("expected the original timesheet bucket to have been removed")
+	}
+
+	if !backend.HasBucket(fmt.Sprintf(state.BackendBucketWorkspaceFmt, types.TrackingStatusDefaultWorkspace)) {
+		t.Error("expected the default workspace bucket to exist")
+	}
+
+	sysGateway := state.NewStoreSysGateway(state.NewBackendStore(backend, state.BackendBucketSys))
+
+	status, err := sysGateway.FindOrCreateStatus()
+	if err != nil {
+		t.Fatalf("unexpected error finding status: %v", err)
+	}
+
+	if status.Workspace != types.TrackingStatusDefaultWorkspace {
+		t.Errorf("expected status workspace to be %q, got %q", types.TrackingStatusDefaultWorkspace, status.Workspace)
+	}
+
+	index, err := sysGateway.FindWorkspaceIndex()
+	if err != nil {
+		t.Fatalf("unexpected error finding workspace index: %v", err)
+	}
+
+	if expected := []string{types.TrackingStatusDefaultWorkspace}; !reflect.DeepEqual(index.Workspaces, expected) {
+		t.Errorf("expected workspaces %v, got %v", expected, index.Workspaces)
+	}
+
+	// Migrating again should be a no-op.
+	if err := migrate.Backend(backend); err != nil {
+		t.Fatalf("unexpected error migrating again: %v", err)
+	}
+
+	index, _ = sysGateway.FindWorkspaceIndex()
+	if len(index.Workspaces) != 1 {
+		t.Errorf("expected migrating again not to change the workspace index, got %v", index.Workspaces)
+	}
+}
+
+func TestBackendOriginalLayout(t *testing.T) {
+	backend := memory.NewMemoryBackend()
+
+	entryHash := strings.Repeat("a", 40)
+	entryKey := fmt.Sprintf(state.KeyEntryFmt, entryHash)
+
+	// Set up data as it was stored before workspaces existed.
+	backend.CreateBucketIfNotExists(state.BackendBucketTimesheet)
+
+	store := state.NewBackendStore(backend, state.BackendBucketTimesheet)
+	store.Write(state.KeyStatus, &proto.SysTrackingStatus{IsRunning: true, Entry: "abc"})
+	store.Write("sheet:2017-03-01", &proto.TrackingTimesheet{Key: "2017-03-01", Entries: []string{"abc"}})
+	store.Write(entryKey, &proto.TrackingEntry{Key: entryHash, Created: 1488369600, Updated: 1488373200, Duration: 3600})
+	store.Write("entry:aaaaaaa", &proto.TrackingEntryRef{Key: "aaaaaaa", Entry: entryHash})
+
+	if err := migrate.Backend(backend); err != nil {
+		t.Fatalf("unexpected error migrating: %v", err)
+	}
+
+	sysStore := state.NewBackendStore(backend, state.BackendBucketSys)
+	workspaceStore := state.NewBackendStore(backend, fmt.Sprintf(
+		state.BackendBucketWorkspaceFmt,
+		types.TrackingStatusDefaultWorkspace,
+	))
+
+	if err := sysStore.Read(state.KeyStatus, &proto.SysTrackingStatus{}); err != nil {
+		t.Fatalf("expected status to have been moved to the sys bucket, got: %v", err)
+	}
+
+	status, err := state.NewStoreSysGateway(sysStore).FindOrCreateStatus()
+	if err != nil {
+		t.Fatalf("unexpected error finding status: %v", err)
+	}
+
+	if !status.IsRunning || status.Entry != "abc" || status.Workspace != types.TrackingStatusDefaultWorkspace {
+		t.Errorf("expected status to be kept, and use the default workspace, got: %+v", status)
+	}
+
+	sheet := &proto.TrackingTimesheet{}
+	if err := workspaceStore.Read("sheet:2017-03-01", sheet); err != nil {
+		t.Fatalf("expected timesheet to have been moved to the default workspace, got: %v", err)
+	}
+
+	if sheet.Key != "2017-03-01" || len(sheet.Entries) != 1 {
+		t.Errorf("expected timesheet to be kept, got: %+v", sheet)
+	}
+
+	if backend.HasBucket(state.BackendBucketTimesheet) {
+		t.Error("expected the original timesheet bucket to have been removed")
+	}
+
+	entry := &proto.TrackingEntry{}
+	if err := workspaceStore.Read(entryKey, entry); err != nil {
+		t.Fatalf("expected entry to have been moved to the default workspace, got: %v", err)
+	}
+
+	created := time.Unix(1488369600, 0).UnixNano()
+	updated := time.Unix(1488373200, 0).UnixNano()
+
+	if entry.CreatedNanos != created || entry.UpdatedNanos != updated || entry.DurationNanos != int64(time.Hour) {
+		t.Errorf("expected entry times to have been converted to nanoseconds, got: %+v", entry)
+	}
+
+	if entry.Created != 0 || entry.Duration != 0 {
+		t.Errorf("expected deprecated second-based fields to have been cleared, got: %+v", entry)
+	}
+
+	ref := &proto.TrackingEntryRef{}
+	if err := workspaceStore.Read("entry:aaaaaaa", ref); err != nil || ref.Entry != entryHash {
+		t.Errorf("expected entry reference to be kept, got: %+v, %v", ref, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/SeerUK/tid/pkg/state/migrate"
 	"github.com/SeerUK/tid/pkg/types"
 	"github.com/SeerUK/tid/pkg/util"
+	"github.com/SeerUK/tid/pkg/xtime"
 )
 
 func init() {
@@ -21,7 +22,7 @@ func (m *Migration1496518709) Description() string {
 
 // Migrate performs the migration.
 func (m *Migration1496518709) Migrate(backend state.Backend) error {
-	factory := util.NewStandardFactory(backend)
+	factory := util.NewStandardFactory(backend, xtime.NewSystemClock())
 	sysGateway := factory.BuildSysGateway()
 
 	status, err := sysGateway.FindOrCreateStatus()
@@ -0,0 +1,96 @@
+package versions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/state"
+	"github.com/SeerUK/tid/pkg/state/migrate"
+	"github.com/SeerUK/tid/proto"
+)
+
+func init() {
+	migrate.RegisterMigration(&Migration1792432285{})
+}
+
+// entryKeyLength is the length of an entry's key in the store, as opposed to the shorter key of a
+// reference to it.
+var entryKeyLength = len(fmt.Sprintf(state.KeyEntryFmt, strings.Repeat("0", 40)))
+
+// Migration1792432285 is a backend migration created at 1792432285 unix time.
+type Migration1792432285 struct{}
+
+// Description provides a description of what the migration is doing.
+func (m *Migration1792432285) Description() string {
+	return "Store entry timestamps and durations in nanoseconds."
+}
+
+// Migrate performs the migration.
+func (m *Migration1792432285) Migrate(backend state.Backend) error {
+	sysGateway := state.NewStoreSysGateway(state.NewBackendStore(backend, state.BackendBucketSys))
+
+	index, err := sysGateway.FindWorkspaceIndex()
+	if err != nil {
+		return err
+	}
+
+	for _, workspace := range index.Workspaces {
+		bucket := fmt.Sprintf(state.BackendBucketWorkspaceFmt, workspace)
+		if !backend.HasBucket(bucket) {
+			continue
+		}
+
+		// Entries are only written once we're done reading, so the backend isn't written to while
+		// it's being iterated over.
+		var keys []string
+
+		err := backend.ForEachSingle(bucket, func(key string, val []byte) error {
+			if strings.HasPrefix(key, "entry:") && len(key) == entryKeyLength {
+				keys = append(keys, key)
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		store := state.NewBackendStore(backend, bucket)
+
+		for _, key := range keys {
+			message := &proto.TrackingEntry{}
+			if err := store.Read(key, message); err != nil {
+				return err
+			}
+
+			if message.CreatedNanos != 0 {
+				continue
+			}
+
+			message.CreatedNanos = time.Unix(int64(message.Created), 0).UnixNano()
+			message.UpdatedNanos = time.Unix(int64(message.Updated), 0).UnixNano()
+			message.DurationNanos = int64(time.Duration(message.Duration) * time.Second)
+			message.PlannedNanos = int64(time.Duration(message.Planned) * time.Second)
+			message.PlannedBreakNanos = int64(time.Duration(message.PlannedBreak) * time.Second)
+
+			message.Created = 0
+			message.Updated = 0
+			message.Duration = 0
+			message.Planned = 0
+			message.PlannedBreak = 0
+
+			if err := store.Write(key, message); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Version returns the version number of the migration.
+func (m *Migration1792432285) Version() uint {
+	return 1792432285
+}
@@ -2,6 +2,8 @@ package state
 
 import (
 	"errors"
+	"sort"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 )
@@ -21,6 +23,8 @@ type Store interface {
 	Write(key string, value proto.Message) error
 	// Delete a value with a given key from the store.
 	Delete(key string) error
+	// Keys returns all of the keys in the store that start with the given prefix, in order.
+	Keys(prefix string) ([]string, error)
 }
 
 // backendStore is a functional Store.
@@ -66,3 +70,23 @@ func (b *backendStore) Write(key string, message proto.Message) error {
 func (b *backendStore) Delete(key string) error {
 	return b.backend.Delete(b.bucket, key)
 }
+
+func (b *backendStore) Keys(prefix string) ([]string, error) {
+	var keys []string
+
+	if !b.backend.HasBucket(b.bucket) {
+		return keys, nil
+	}
+
+	err := b.backend.ForEachSingle(b.bucket, func(key string, val []byte) error {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+
+		return nil
+	})
+
+	sort.Strings(keys)
+
+	return keys, err
+}
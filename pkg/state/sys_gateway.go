@@ -1,11 +1,20 @@
 package state
 
 import (
+	"fmt"
+
 	"github.com/SeerUK/tid/pkg/types"
 	"github.com/SeerUK/tid/proto"
+	protobuf "github.com/golang/protobuf/proto"
 )
 
 const (
+	// KeyCalendar is the key for the calendar of non-working days in the store.
+	KeyCalendar = "calendar"
+	// KeyJournal is the key for the journal of operations in the store.
+	KeyJournal = "journal"
+	// KeyJournalOperationFmt is the formatting string for journal operation keys in the store.
+	KeyJournalOperationFmt = "journal:%s"
 	// KeyMigrations is the key for the applied migration versions in the store.
 	KeyMigrations = "migration_versions"
 	// KeyStatus is the key for the current tracking status in the store.
@@ -16,6 +25,14 @@ const (
 
 // SysGateway provides access to tid system data in the database.
 type SysGateway interface {
+	// FindOrCreateCalendar attempts to find the calendar of non-working days, if one is not in the
+	// store then a new types.Calendar object is instantiated.
+	FindOrCreateCalendar() (types.Calendar, error)
+	// FindOrCreateJournal attempts to find the journal of operations, if one is not in the store
+	// then a new types.Journal object is instantiated.
+	FindOrCreateJournal() (types.Journal, error)
+	// FindJournalOperation attempts to find a journal operation with the given key.
+	FindJournalOperation(key string) (types.JournalOperation, error)
 	// FindOrCreateMigrationsStatus attempts to find the current migrations information, if it can't
 	// find any in the store then a new types.Migrations object is instantiated.
 	FindOrCreateMigrationsStatus() (types.MigrationsStatus, error)
@@ -24,6 +41,14 @@ type SysGateway interface {
 	FindOrCreateStatus() (types.TrackingStatus, error)
 	// FindWorkspaceIndex attempts to find the workspace index in the store.
 	FindWorkspaceIndex() (types.WorkspaceIndex, error)
+	// PersistCalendar persists a given types.Calendar to the store.
+	PersistCalendar(calendar types.Calendar) error
+	// PersistJournal persists a given types.Journal to the store.
+	PersistJournal(journal types.Journal) error
+	// PersistJournalOperation persists a given types.JournalOperation to the store.
+	PersistJournalOperation(operation types.JournalOperation) error
+	// DeleteJournalOperation deletes a given types.JournalOperation from the store.
+	DeleteJournalOperation(key string) error
 	// PersistMigrations persists a given types.Migrations to the store.
 	PersistMigrations(migrations types.MigrationsStatus) error
 	// PersistStatus persists a given types.Status to the store.
@@ -45,6 +70,53 @@ func NewStoreSysGateway(store Store) SysGateway {
 	}
 }
 
+func (g *storeSysGateway) FindOrCreateCalendar() (types.Calendar, error) {
+	calendar := types.NewCalendar()
+	message := &proto.SysCalendar{}
+
+	err := g.store.Read(KeyCalendar, message)
+	if err != nil && err != ErrStoreNilResult {
+		return calendar, err
+	}
+
+	if err == nil {
+		calendar.FromMessage(message)
+	}
+
+	return calendar, nil
+}
+
+func (g *storeSysGateway) FindOrCreateJournal() (types.Journal, error) {
+	journal := types.NewJournal()
+	message := &proto.SysJournal{}
+
+	err := g.store.Read(KeyJournal, message)
+	if err != nil && err != ErrStoreNilResult {
+		return journal, err
+	}
+
+	if err == nil {
+		journal.FromMessage(message)
+	}
+
+	return journal, nil
+}
+
+func (g *storeSysGateway) FindJournalOperation(key string) (types.JournalOperation, error) {
+	operation := types.JournalOperation{}
+	message := &proto.SysJournalOperation{}
+
+	err := g.store.Read(fmt.Sprintf(KeyJournalOperationFmt, key), message)
+	if err != nil {
+		return operation, err
+	}
+
+	operation.FromMessage(message)
+	operation.Entries, err = journalEntries(operation)
+
+	return operation, err
+}
+
 func (g *storeSysGateway) FindOrCreateMigrationsStatus() (types.MigrationsStatus, error) {
 	migrations := types.NewMigrationsStatus()
 	message := &proto.SysMigrationsStatus{}
@@ -91,6 +163,22 @@ func (g *storeSysGateway) FindWorkspaceIndex() (types.WorkspaceIndex, error) {
 	return index, nil
 }
 
+func (g *storeSysGateway) PersistCalendar(calendar types.Calendar) error {
+	return g.store.Write(KeyCalendar, calendar.ToMessage())
+}
+
+func (g *storeSysGateway) PersistJournal(journal types.Journal) error {
+	return g.store.Write(KeyJournal, journal.ToMessage())
+}
+
+func (g *storeSysGateway) PersistJournalOperation(operation types.JournalOperation) error {
+	return g.store.Write(fmt.Sprintf(KeyJournalOperationFmt, operation.Key), operation.ToMessage())
+}
+
+func (g *storeSysGateway) DeleteJournalOperation(key string) error {
+	return g.store.Delete(fmt.Sprintf(KeyJournalOperationFmt, key))
+}
+
 func (g *storeSysGateway) PersistMigrations(migrations types.MigrationsStatus) error {
 	return g.store.Write(KeyMigrations, migrations.ToMessage())
 }
@@ -102,3 +190,34 @@ func (g *storeSysGateway) PersistStatus(status types.TrackingStatus) error {
 func (g *storeSysGateway) PersistWorkspaceIndex(index types.WorkspaceIndex) error {
 	return g.store.Write(KeyWorkspaceIndex, index.ToMessage())
 }
+
+// journalEntries returns the entries changed by the given operation, as they were after it, or as
+// they were before it if it deleted them.
+func journalEntries(operation types.JournalOperation) ([]types.Entry, error) {
+	var entries []types.Entry
+
+	for _, change := range operation.Changes {
+		// Entries are stored under their full hash, and referenced by their short hash.
+		var hash string
+		if _, err := fmt.Sscanf(change.Key, KeyEntryFmt, &hash); err != nil || len(hash) != 40 {
+			continue
+		}
+
+		value := change.After
+		if change.Deleted {
+			value = change.Before
+		}
+
+		message := &proto.TrackingEntry{}
+		if err := protobuf.Unmarshal(value, message); err != nil {
+			return entries, err
+		}
+
+		entry := types.Entry{}
+		entry.FromMessage(message)
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -3,10 +3,12 @@ package state
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/SeerUK/tid/pkg/errhandling"
 	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
 	"github.com/SeerUK/tid/proto"
 )
 
@@ -15,6 +17,8 @@ const (
 	KeyEntryFmt = "entry:%s"
 	// KeyTimesheetFmt is the formatting string for the timesheet keys in the store.
 	KeyTimesheetFmt = "sheet:%s"
+	// KeyEntryLogFmt is the formatting string for the entry log keys in the store.
+	KeyEntryLogFmt = "entry_log:%s"
 )
 
 // @todo: Consider splitting this up, we have facades for common tasks more granularly than this.
@@ -27,6 +31,9 @@ type TrackingGateway interface {
 	FindEntryHashByShortHash(hash string) (string, error)
 	// FindEntriesInDateRange attempts to find all of the entries within a given start and end date.
 	FindEntriesInDateRange(start time.Time, end time.Time) ([]types.Entry, error)
+	// FindOrCreateEntryLog attempts to find the log of revisions made to the entry with the given
+	// hash, if one is not in the store then a new, empty, log is instantiated.
+	FindOrCreateEntryLog(hash string) (types.EntryLog, error)
 	// FindTimesheet attempts to find a timesheet for the given date.
 	FindTimesheet(key string) (types.Timesheet, error)
 	// FindOrCreateTimesheet attempts to find a timesheet for the given date, if one is not in the
@@ -37,13 +44,21 @@ type TrackingGateway interface {
 	FindOrCreateTodaysTimesheet() (types.Timesheet, error)
 	// FindTimesheetsInDateRange attempts to find all timesheets within a given start and end date.
 	FindTimesheetsInDateRange(start time.Time, end time.Time) ([]types.Timesheet, error)
-	// FindTimesheets attempts to find all timesheets.
+	// FindTimesheets attempts to find all timesheets, oldest first.
 	FindTimesheets() ([]types.Timesheet, error)
-	// PersistEntry persists a given entry to the store.
+	// FindTimesheetKeys attempts to find the keys of all timesheets, oldest first.
+	FindTimesheetKeys() ([]string, error)
+	// PersistEntry persists a given entry to the store, as updated now.
 	PersistEntry(entry types.Entry) error
+	// PersistEntryAsOf persists a given entry to the store, as updated at the given time, e.g. to
+	// stop an entry as of when it should have stopped, rather than when that was noticed.
+	PersistEntryAsOf(entry types.Entry, updated time.Time) error
 	// PersistTimesheet persists a given timesheet to the store.
 	PersistTimesheet(timesheet types.Timesheet) error
-	// DeleteEntry attempts to delete an entry from the store.
+	// AppendEntryRevision appends a given revision to the log of the entry with the given hash.
+	AppendEntryRevision(hash string, revision types.EntryRevision) error
+	// DeleteEntry attempts to delete an entry from the store, along with it's log. The log only
+	// describes the entry, so it's of no use once the entry is gone.
 	DeleteEntry(entry types.Entry) error
 	// DeleteTimesheet attempts to delete a timesheet from the store.
 	DeleteTimesheet(sheet types.Timesheet) error
@@ -55,18 +70,21 @@ type storeTrackingGateway struct {
 	store Store
 	// A SysGateway to lookup system info.
 	sysGateway SysGateway
+	// A Clock to get the current time from.
+	clock xtime.Clock
 }
 
 // NewStoreTrackingGateway creates a new timesheet gateway.
-func NewStoreTrackingGateway(store Store, sysGateway SysGateway) TrackingGateway {
+func NewStoreTrackingGateway(store Store, sysGateway SysGateway, clock xtime.Clock) TrackingGateway {
 	return &storeTrackingGateway{
 		store:      store,
 		sysGateway: sysGateway,
+		clock:      clock,
 	}
 }
 
 func (g *storeTrackingGateway) FindEntry(hash string) (types.Entry, error) {
-	entry := types.NewEntry()
+	entry := types.NewEntry(g.clock.Now())
 
 	status, err := g.sysGateway.FindOrCreateStatus()
 	if err != nil {
@@ -93,7 +111,7 @@ func (g *storeTrackingGateway) FindEntry(hash string) (types.Entry, error) {
 	entry.IsRunning = status.IsRunning && status.Entry == entry.Hash
 
 	if entry.IsRunning {
-		entry.UpdateDuration()
+		entry.UpdateDuration(g.clock.Now())
 	}
 
 	return entry, nil
@@ -125,8 +143,34 @@ func (g *storeTrackingGateway) FindEntriesInDateRange(start time.Time, end time.
 	return entries, nil
 }
 
+func (g *storeTrackingGateway) FindOrCreateEntryLog(hash string) (types.EntryLog, error) {
+	log := types.NewEntryLog(hash)
+
+	if len(hash) == 7 {
+		longHash, err := g.FindEntryHashByShortHash(hash)
+		if err != nil {
+			return log, err
+		}
+
+		log.Entry = longHash
+	}
+
+	message := &proto.TrackingEntryLog{}
+
+	err := g.store.Read(fmt.Sprintf(KeyEntryLogFmt, log.Entry), message)
+	if err != nil && err != ErrStoreNilResult {
+		return log, err
+	}
+
+	if err == nil {
+		log.FromMessage(message)
+	}
+
+	return log, nil
+}
+
 func (g *storeTrackingGateway) FindTimesheet(sheetKey string) (types.Timesheet, error) {
-	sheet := types.NewTimesheet()
+	sheet := types.NewTimesheet(g.clock.Now())
 	sheet.Key = sheetKey
 
 	message := &proto.TrackingTimesheet{}
@@ -162,7 +206,7 @@ func (g *storeTrackingGateway) FindOrCreateTimesheet(sheetKey string) (types.Tim
 }
 
 func (g *storeTrackingGateway) FindOrCreateTodaysTimesheet() (types.Timesheet, error) {
-	return g.FindOrCreateTimesheet(time.Now().Local().Format(types.TimesheetKeyDateFmt))
+	return g.FindOrCreateTimesheet(g.clock.Now().Format(types.TimesheetKeyDateFmt))
 }
 
 func (g *storeTrackingGateway) FindTimesheetsInDateRange(start time.Time, end time.Time) ([]types.Timesheet, error) {
@@ -193,18 +237,53 @@ func (g *storeTrackingGateway) FindTimesheetsInDateRange(start time.Time, end ti
 func (g *storeTrackingGateway) FindTimesheets() ([]types.Timesheet, error) {
 	var sheets []types.Timesheet
 
+	keys, err := g.FindTimesheetKeys()
+	if err != nil {
+		return sheets, err
+	}
+
+	for _, key := range keys {
+		sheet, err := g.FindTimesheet(key)
+		if err != nil {
+			return sheets, err
+		}
+
+		sheets = append(sheets, sheet)
+	}
+
 	return sheets, nil
 }
 
+func (g *storeTrackingGateway) FindTimesheetKeys() ([]string, error) {
+	var keys []string
+
+	prefix := fmt.Sprintf(KeyTimesheetFmt, "")
+
+	storeKeys, err := g.store.Keys(prefix)
+	if err != nil {
+		return keys, err
+	}
+
+	for _, key := range storeKeys {
+		keys = append(keys, strings.TrimPrefix(key, prefix))
+	}
+
+	return keys, nil
+}
+
 func (g *storeTrackingGateway) PersistEntry(entry types.Entry) error {
+	// Every time we do anything to an entry, we should update when it was updated. This helps keep
+	// things properly in sync.
+	return g.PersistEntryAsOf(entry, g.clock.Now())
+}
+
+func (g *storeTrackingGateway) PersistEntryAsOf(entry types.Entry, updated time.Time) error {
 	entryRef := &proto.TrackingEntryRef{
 		Key:   entry.ShortHash(),
 		Entry: entry.Hash,
 	}
 
-	// Every time we do anything to an entry, we should update when it was updated. This helps keep
-	// things properly in sync.
-	entry.Updated = time.Now()
+	entry.Updated = updated
 
 	// Persisting an entry is a 2-step process, as we need to also store the short-key so we can
 	// look up the long key.
@@ -219,10 +298,22 @@ func (g *storeTrackingGateway) PersistTimesheet(sheet types.Timesheet) error {
 	return g.store.Write(fmt.Sprintf(KeyTimesheetFmt, sheet.Key), sheet.ToMessage())
 }
 
+func (g *storeTrackingGateway) AppendEntryRevision(hash string, revision types.EntryRevision) error {
+	log, err := g.FindOrCreateEntryLog(hash)
+	if err != nil {
+		return err
+	}
+
+	log.Append(revision)
+
+	return g.store.Write(fmt.Sprintf(KeyEntryLogFmt, log.Entry), log.ToMessage())
+}
+
 func (g *storeTrackingGateway) DeleteEntry(entry types.Entry) error {
 	errs := errhandling.NewErrorStack()
 	errs.Add(g.store.Delete(fmt.Sprintf(KeyEntryFmt, entry.ShortHash())))
 	errs.Add(g.store.Delete(fmt.Sprintf(KeyEntryFmt, entry.Hash)))
+	errs.Add(g.store.Delete(fmt.Sprintf(KeyEntryLogFmt, entry.Hash)))
 
 	return errs.Errors()
 }
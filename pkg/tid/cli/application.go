@@ -4,12 +4,18 @@ import (
 	"fmt"
 
 	"github.com/SeerUK/tid/pkg/tid/cli/command"
+	"github.com/SeerUK/tid/pkg/tid/cli/command/calendar"
 	"github.com/SeerUK/tid/pkg/tid/cli/command/entry"
 	"github.com/SeerUK/tid/pkg/tid/cli/command/timesheet"
 	"github.com/SeerUK/tid/pkg/tid/cli/command/workspace"
 	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
 )
 
+// DataDirOption is the option used to set the directory that tid files are stored in. It's handled
+// before the application is created, it's only defined on the application for help output.
+const DataDirOption = "--data-dir"
+
 var (
 	// BuildTime should be set to a datetime string.
 	BuildTime = "n/a"
@@ -33,6 +39,16 @@ func CreateApplication(kernel *TidKernel) *console.Application {
    ###   ###  ######
 `
 
+	application.Configure = func(def *console.Definition) {
+		var dataDir string
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&dataDir),
+			Spec:  DataDirOption + "=DATA_DIR",
+			Desc:  "The directory to store tid files in. (Default: $TID_HOME, or ~/.tid)",
+		})
+	}
+
 	application.AddCommands(buildCommands(kernel))
 
 	return application
@@ -41,17 +57,28 @@ func CreateApplication(kernel *TidKernel) *console.Application {
 // buildCommands instantiates all of the commands registered in the application.
 func buildCommands(kernel *TidKernel) []*console.Command {
 	return []*console.Command{
+		// Calendar commands
+		calendar.RootCommand().AddCommands([]*console.Command{
+			calendar.AddCommand(kernel.Factory),
+			calendar.DeleteCommand(kernel.Factory),
+			calendar.ImportCommand(kernel.Factory),
+			calendar.ListCommand(kernel.Factory, kernel.Config),
+		}),
+
 		// Entry commands
 		entry.RootCommand().AddCommands([]*console.Command{
 			entry.CreateCommand(kernel.Factory),
-			entry.DeleteCommand(kernel.Factory),
+			entry.DeleteCommand(kernel.Factory, kernel.Opener, kernel.Config),
+			entry.EditCommand(kernel.Factory, kernel.Opener),
 			entry.ListCommand(kernel.Factory, kernel.Config),
-			entry.UpdateCommand(kernel.Factory),
+			entry.LogCommand(kernel.Factory, kernel.Config),
+			entry.UpdateCommand(kernel.Factory, kernel.Opener, kernel.Config),
 		}),
 
 		// Timesheet commands
 		timesheet.RootCommand().AddCommands([]*console.Command{
 			timesheet.DeleteCommand(kernel.Factory),
+			timesheet.EditCommand(kernel.Factory, kernel.Opener),
 			timesheet.ListCommand(kernel.Factory, kernel.Config),
 		}),
 
@@ -60,13 +87,18 @@ func buildCommands(kernel *TidKernel) []*console.Command {
 			workspace.CreateCommand(kernel.Factory),
 			workspace.DeleteCommand(kernel.Factory),
 			workspace.ListCommand(kernel.Factory),
-			workspace.SwitchCommand(kernel.Factory),
+			workspace.SwitchCommand(kernel.Factory, kernel.Config),
 		}),
 
+		command.BalanceCommand(kernel.Factory, kernel.Config),
+		command.HistoryCommand(kernel.Factory),
+		command.RedoCommand(kernel.Factory),
 		command.ReportCommand(kernel.Factory, kernel.Config),
-		command.ResumeCommand(kernel.Factory),
-		command.StartCommand(kernel.Factory),
-		command.StatusCommand(kernel.Factory, kernel.Config),
-		command.StopCommand(kernel.Factory),
+		command.ResumeCommand(kernel.Factory, kernel.Config),
+		command.SearchCommand(kernel.Factory, kernel.Config),
+		command.StartCommand(kernel.Factory, kernel.Config),
+		command.StatusCommand(kernel.Factory, kernel.Config, kernel.Backend, kernel.Connector),
+		command.StopCommand(kernel.Factory, kernel.Config),
+		command.UndoCommand(kernel.Factory),
 	}
 }
@@ -0,0 +1,124 @@
+package command
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/tid/cli/display"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/SeerUK/tid/pkg/xtime"
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+)
+
+// BalanceCommand creates a command to view the balance of time worked against targets.
+func BalanceCommand(factory util.Factory, config types.Config) *console.Command {
+	var end time.Time
+	var start time.Time
+
+	configure := func(def *console.Definition) {
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewDateValue(&end),
+			Spec:  "-e, --end=END",
+			Desc:  "The end date of the balance period. (Default: today)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewDateValue(&start),
+			Spec:  "-s, --start=START",
+			Desc:  "The start date of the balance period. (Default: start of the month)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.TimeFormat,
+			Spec:  "-t, --time-format=TIME_FORMAT",
+			Desc:  "Duration format: decimal, text, clock, clock-seconds, minutes, or iso8601. (Default: from config)",
+		})
+	}
+
+	execute := func(input *console.Input, output *console.Output) error {
+		sysGateway := factory.BuildSysGateway()
+		trGateway := factory.BuildTrackingGateway()
+
+		hasEnd := input.HasOption([]string{"e", "end"})
+		hasStart := input.HasOption([]string{"s", "start"})
+
+		now := xtime.Date(factory.BuildClock().Now())
+
+		if !hasStart {
+			start = now.AddDate(0, 0, 1-now.Day())
+		}
+
+		if !hasEnd {
+			end = now
+		}
+
+		status, err := sysGateway.FindOrCreateStatus()
+		if err != nil {
+			return err
+		}
+
+		sheets, err := trGateway.FindTimesheetsInDateRange(start, end)
+		if err != nil {
+			return err
+		}
+
+		calendar, err := sysGateway.FindOrCreateCalendar()
+		if err != nil {
+			return err
+		}
+
+		report := types.NewReport(start, end, status.Workspace, sheets, config, calendar)
+
+		if report.Target == 0 {
+			return errors.New("balance: No targets are configured within the given time period")
+		}
+
+		weeks := splitReportIntoWeeks(report, sheets, config, calendar)
+
+		output.Printf("Balance for %s.\n\n", report.DateRange())
+
+		display.WriteBalanceTable(weeks, output.Writer, config)
+
+		return nil
+	}
+
+	return &console.Command{
+		Name:        "balance",
+		Alias:       "bal",
+		Description: "Display the balance of time worked against targets.",
+		Configure:   configure,
+		Execute:     execute,
+	}
+}
+
+// splitReportIntoWeeks creates a report for each week (beginning on the configured first weekday)
+// within the given report's date range, using the given timesheets and calendar.
+func splitReportIntoWeeks(report types.Report, sheets []types.Timesheet, config types.Config, calendar types.Calendar) []types.Report {
+	var weeks []types.Report
+
+	firstWeekday := config.Display.FirstWeekday.TimeWeekday()
+
+	for weekStart := report.Start; !weekStart.After(report.End); {
+		weekEnd := weekStart
+
+		for weekEnd.Before(report.End) && weekEnd.AddDate(0, 0, 1).Weekday() != firstWeekday {
+			weekEnd = weekEnd.AddDate(0, 0, 1)
+		}
+
+		var weekSheets []types.Timesheet
+
+		for _, sheet := range sheets {
+			date, err := time.Parse(types.TimesheetKeyDateFmt, sheet.Key)
+			if err == nil && !date.Before(weekStart) && !date.After(weekEnd) {
+				weekSheets = append(weekSheets, sheet)
+			}
+		}
+
+		weeks = append(weeks, types.NewReport(weekStart, weekEnd, report.Workspace, weekSheets, config, calendar))
+		weekStart = weekEnd.AddDate(0, 0, 1)
+	}
+
+	return weeks
+}
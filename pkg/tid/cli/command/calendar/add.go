@@ -0,0 +1,50 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+)
+
+// AddCommand creates a command that is used to add non-working days.
+func AddCommand(factory util.Factory) *console.Command {
+	var date time.Time
+	var reason string
+
+	configure := func(def *console.Definition) {
+		def.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewDateValue(&date),
+			Spec:  "DATE",
+			Desc:  "The date of the non-working day.",
+		})
+
+		def.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringValue(&reason),
+			Spec:  "[REASON]",
+			Desc:  "Why is this a non-working day?",
+		})
+	}
+
+	execute := func(input *console.Input, output *console.Output) error {
+		facade := factory.BuildCalendarFacade()
+
+		day, err := facade.Add(date, reason)
+		if err != nil {
+			return err
+		}
+
+		output.Printf("Added non-working day '%s'\n", day.Date)
+
+		return nil
+	}
+
+	return &console.Command{
+		Name:        "add",
+		Alias:       "a",
+		Description: "Add a non-working day.",
+		Configure:   configure,
+		Execute:     execute,
+	}
+}
@@ -0,0 +1,43 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+)
+
+// DeleteCommand creates a command that is used to delete non-working days.
+func DeleteCommand(factory util.Factory) *console.Command {
+	var date time.Time
+
+	configure := func(def *console.Definition) {
+		def.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewDateValue(&date),
+			Spec:  "DATE",
+			Desc:  "The date of the non-working day.",
+		})
+	}
+
+	execute := func(input *console.Input, output *console.Output) error {
+		facade := factory.BuildCalendarFacade()
+
+		day, err := facade.Delete(date)
+		if err != nil {
+			return err
+		}
+
+		output.Printf("Deleted non-working day '%s'\n", day.Date)
+
+		return nil
+	}
+
+	return &console.Command{
+		Name:        "delete",
+		Alias:       "d",
+		Description: "Delete a non-working day.",
+		Configure:   configure,
+		Execute:     execute,
+	}
+}
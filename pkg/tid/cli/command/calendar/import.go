@@ -0,0 +1,56 @@
+package calendar
+
+import (
+	"os"
+
+	"github.com/SeerUK/tid/pkg/ics"
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+)
+
+// ImportCommand creates a command that is used to import non-working days from an iCalendar file.
+func ImportCommand(factory util.Factory) *console.Command {
+	var path string
+
+	configure := func(def *console.Definition) {
+		def.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringValue(&path),
+			Spec:  "FILE",
+			Desc:  "The path to an iCalendar (.ics) file.",
+		})
+	}
+
+	execute := func(input *console.Input, output *console.Output) error {
+		facade := factory.BuildCalendarFacade()
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+
+		days, err := ics.ParseDays(file)
+		if err != nil {
+			return err
+		}
+
+		err = facade.Import(days)
+		if err != nil {
+			return err
+		}
+
+		output.Printf("Imported %d non-working days from '%s'\n", len(days), path)
+
+		return nil
+	}
+
+	return &console.Command{
+		Name:        "import",
+		Alias:       "i",
+		Description: "Import non-working days from an iCalendar (.ics) file.",
+		Configure:   configure,
+		Execute:     execute,
+	}
+}
@@ -0,0 +1,69 @@
+package calendar
+
+import (
+	"errors"
+
+	"github.com/SeerUK/tid/pkg/tid/cli/display"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+)
+
+// ListCommand creates a command to list non-working days.
+func ListCommand(factory util.Factory, config types.Config) *console.Command {
+	var format string
+
+	configure := func(def *console.Definition) {
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&format),
+			Spec:  "-f, --format=FORMAT",
+			Desc:  "Output formatting string. Uses Go templates.",
+		})
+	}
+
+	execute := func(input *console.Input, output *console.Output) error {
+		sysGateway := factory.BuildSysGateway()
+
+		hasFormat := input.HasOption([]string{"f", "format"})
+
+		calendar, err := sysGateway.FindOrCreateCalendar()
+		if err != nil {
+			return err
+		}
+
+		if hasFormat {
+			tmpl, err := display.ParseTemplate("calendar-list", format, config)
+			if err != nil {
+				return err
+			}
+
+			for _, day := range calendar.Days {
+				err = tmpl.Execute(output.Writer, day)
+				if err != nil {
+					return err
+				}
+
+				output.Println()
+			}
+
+			return nil
+		}
+
+		if len(calendar.Days) == 0 {
+			return errors.New("list: No non-working days in the calendar")
+		}
+
+		display.WriteCalendarTable(calendar.Days, output.Writer)
+
+		return nil
+	}
+
+	return &console.Command{
+		Name:        "list",
+		Alias:       "ls",
+		Description: "List non-working days.",
+		Configure:   configure,
+		Execute:     execute,
+	}
+}
@@ -0,0 +1,12 @@
+package calendar
+
+import "github.com/eidolon/console"
+
+// RootCommand creates a new command that is the parent to all calendar-related sub-commands.
+func RootCommand() *console.Command {
+	return &console.Command{
+		Name:        "calendar",
+		Alias:       "cal",
+		Description: "Manage non-working days, e.g. holidays and leave.",
+	}
+}
@@ -0,0 +1,173 @@
+package entry
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/tid/cli/display"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+)
+
+// bulkOptions are the options used to select entries to change in bulk, instead of by hash.
+type bulkOptions struct {
+	date     time.Time
+	end      time.Time
+	matching string
+	start    time.Time
+	where    string
+	yes      bool
+}
+
+// configure adds the options used to select entries in bulk to the given definition.
+func (o *bulkOptions) configure(def *console.Definition) {
+	def.AddOption(console.OptionDefinition{
+		Value: parameters.NewDateValue(&o.date),
+		Spec:  "--date=DATE",
+		Desc:  "Only select entries on the timesheet for the given date.",
+	})
+
+	def.AddOption(console.OptionDefinition{
+		Value: parameters.NewDateValue(&o.end),
+		Spec:  "--end=END",
+		Desc:  "Only select entries on timesheets up to the given date.",
+	})
+
+	def.AddOption(console.OptionDefinition{
+		Value: parameters.NewStringValue(&o.matching),
+		Spec:  "-m, --matching=REGEX",
+		Desc:  "Only select entries with notes matching the given regular expression.",
+	})
+
+	def.AddOption(console.OptionDefinition{
+		Value: parameters.NewDateValue(&o.start),
+		Spec:  "--start=START",
+		Desc:  "Only select entries on timesheets from the given date.",
+	})
+
+	def.AddOption(console.OptionDefinition{
+		Value: parameters.NewStringValue(&o.where),
+		Spec:  "-w, --where=FILTER",
+		Desc:  "Only select entries matching the given filter, e.g. 'note~\"JIRA-12\" and duration>1h'.",
+	})
+
+	def.AddOption(console.OptionDefinition{
+		Value: parameters.NewBoolValue(&o.yes),
+		Spec:  "-y, --yes",
+		Desc:  "Don't ask for confirmation before changing the selected entries.",
+	})
+}
+
+// isSet returns true if any of the options used to select entries were given.
+func (o *bulkOptions) isSet(input *console.Input) bool {
+	return input.HasOption([]string{"date"}) ||
+		input.HasOption([]string{"end"}) ||
+		input.HasOption([]string{"m", "matching"}) ||
+		input.HasOption([]string{"start"}) ||
+		input.HasOption([]string{"w", "where"})
+}
+
+// filter builds an EntryFilter from the options used to select entries. An empty filter or pattern
+// would select every entry, so they're rejected.
+func (o *bulkOptions) filter(input *console.Input) (types.EntryFilter, error) {
+	var filter types.EntryFilter
+	var conditions [][3]string
+	var err error
+
+	if input.HasOption([]string{"w", "where"}) {
+		if filter, err = types.ParseEntryFilter(o.where); err != nil {
+			return filter, err
+		}
+
+		if filter.IsEmpty() {
+			return filter, errors.New("entry: The filter given to --where must not be empty")
+		}
+	}
+
+	if input.HasOption([]string{"date"}) {
+		conditions = append(conditions, [3]string{"date", "=", o.date.Format(types.TimesheetKeyDateFmt)})
+	}
+
+	if input.HasOption([]string{"start"}) {
+		conditions = append(conditions, [3]string{"date", ">=", o.start.Format(types.TimesheetKeyDateFmt)})
+	}
+
+	if input.HasOption([]string{"end"}) {
+		conditions = append(conditions, [3]string{"date", "<=", o.end.Format(types.TimesheetKeyDateFmt)})
+	}
+
+	if input.HasOption([]string{"m", "matching"}) {
+		if o.matching == "" {
+			return filter, errors.New("entry: The pattern given to --matching must not be empty")
+		}
+
+		conditions = append(conditions, [3]string{"note", "~", o.matching})
+	}
+
+	for _, condition := range conditions {
+		if err := filter.Add(condition[0], condition[1], condition[2]); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, nil
+}
+
+// find finds the entries selected by the options used to select entries. An error is returned if
+// no entries are selected.
+func (o *bulkOptions) find(facade *util.EntryFacade, input *console.Input) ([]types.Entry, error) {
+	filter, err := o.filter(input)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := facade.FindMatching(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.New("entry: No entries match the given filter")
+	}
+
+	return entries, nil
+}
+
+// confirm shows the given entries, and asks the user to confirm that the given action should be
+// done to them, unless the yes option was given. Only an answer of "y" or "yes" confirms it.
+func (o *bulkOptions) confirm(action string, entries []types.Entry, output *console.Output, reader io.Reader, config types.Config) (bool, error) {
+	display.WriteEntriesTable(entries, output.Writer, config)
+
+	if o.yes {
+		return true, nil
+	}
+
+	output.Printf("%s %d %s? [y/N]: ", action, len(entries), pluralise("entry", "entries", len(entries)))
+
+	answer, err := bufio.NewReader(reader).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+
+	if err == io.EOF {
+		output.Println()
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes", nil
+}
+
+// pluralise returns the given singular word if count is 1, otherwise the given plural word.
+func pluralise(singular string, plural string, count int) string {
+	if count == 1 {
+		return singular
+	}
+
+	return plural
+}
@@ -12,7 +12,7 @@ import (
 func CreateCommand(factory util.Factory) *console.Command {
 	var duration time.Duration
 	var note string
-	var started = time.Now()
+	var started time.Time
 
 	configure := func(def *console.Definition) {
 		def.AddArgument(console.ArgumentDefinition{
@@ -37,6 +37,10 @@ func CreateCommand(factory util.Factory) *console.Command {
 	execute := func(input *console.Input, output *console.Output) error {
 		facade := factory.BuildEntryFacade()
 
+		if !input.HasOption([]string{"d", "date"}) {
+			started = factory.BuildClock().Now()
+		}
+
 		entry, err := facade.Create(started, duration, note)
 		if err != nil {
 			return err
@@ -1,32 +1,85 @@
 package entry
 
 import (
+	"errors"
+	"os"
+
+	"github.com/SeerUK/tid/pkg/types"
 	"github.com/SeerUK/tid/pkg/util"
 	"github.com/eidolon/console"
 	"github.com/eidolon/console/parameters"
 )
 
-// DeleteCommand creates a command that is used to delete timesheet entries.
-func DeleteCommand(factory util.Factory) *console.Command {
+// DeleteCommand creates a command that is used to delete timesheet entries, either by hash, or in
+// bulk by filter. Entries are found with the given factory, and deleted with a factory from the
+// given opener once the user has confirmed it, so the database isn't locked while they decide.
+func DeleteCommand(factory util.Factory, opener util.FactoryOpener, config types.Config) *console.Command {
+	var bulk bulkOptions
 	var hash string
 
 	configure := func(def *console.Definition) {
 		def.AddArgument(console.ArgumentDefinition{
 			Value: parameters.NewStringValue(&hash),
-			Spec:  "HASH",
+			Spec:  "[HASH]",
 			Desc:  "A short or long hash for an entry.",
 		})
+
+		bulk.configure(def)
 	}
 
 	execute := func(input *console.Input, output *console.Output) error {
-		facade := factory.BuildEntryFacade()
+		if hash != "" && bulk.isSet(input) {
+			return errors.New("delete: A hash and a filter are mutually exclusive")
+		}
+
+		if hash == "" && !bulk.isSet(input) {
+			return errors.New("delete: Either a hash, or a filter is required")
+		}
+
+		if hash != "" {
+			writable, release, err := opener()
+			if err != nil {
+				return err
+			}
+
+			defer release()
+
+			entry, err := writable.BuildEntryFacade().Delete(hash)
+			if err != nil {
+				return err
+			}
+
+			output.Printf("Deleted entry '%s' (%s)\n", entry.Note, entry.ShortHash())
+
+			return nil
+		}
+
+		entries, err := bulk.find(factory.BuildEntryFacade(), input)
+		if err != nil {
+			return err
+		}
+
+		confirmed, err := bulk.confirm("Delete", entries, output, os.Stdin, config)
+		if err != nil || !confirmed {
+			output.Println("Cancelled, nothing was deleted")
+			return err
+		}
 
-		entry, err := facade.Delete(hash)
+		writable, release, err := opener()
 		if err != nil {
 			return err
 		}
 
-		output.Printf("Deleted entry '%s' (%s)\n", entry.Note, entry.ShortHash())
+		defer release()
+
+		deleted, err := writable.BuildEntryFacade().DeleteMany(entries)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range deleted {
+			output.Printf("Deleted entry '%s' (%s)\n", entry.Note, entry.ShortHash())
+		}
 
 		return nil
 	}
@@ -34,7 +87,7 @@ func DeleteCommand(factory util.Factory) *console.Command {
 	return &console.Command{
 		Name:        "delete",
 		Alias:       "d",
-		Description: "Delete a timesheet entry.",
+		Description: "Delete a timesheet entry, or every entry matching a filter.",
 		Configure:   configure,
 		Execute:     execute,
 	}
@@ -0,0 +1,69 @@
+package entry
+
+import (
+	"errors"
+
+	"github.com/SeerUK/tid/pkg/tid/cli/editor"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+)
+
+// EditCommand creates a command to edit a timesheet entry in the user's editor. The entry is read
+// with the given factory, and the changes are made with a factory from the given opener once the
+// editor exits, so the database isn't locked while the editor is open.
+func EditCommand(factory util.Factory, opener util.FactoryOpener) *console.Command {
+	var hash string
+
+	configure := func(def *console.Definition) {
+		def.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringValue(&hash),
+			Spec:  "HASH",
+			Desc:  "A short or long hash for an entry.",
+		})
+	}
+
+	execute := func(input *console.Input, output *console.Output) error {
+		entry, err := factory.BuildTrackingGateway().FindEntry(hash)
+		if err != nil {
+			return err
+		}
+
+		edited, err := editor.EditEntries([]types.Entry{entry})
+		if err != nil {
+			return err
+		}
+
+		if len(edited) == 0 {
+			return errors.New("entry: No changes made")
+		}
+
+		writable, release, err := opener()
+		if err != nil {
+			return err
+		}
+
+		defer release()
+
+		changed, err := writable.BuildEntryFacade().Edit([]types.Entry{entry}, edited)
+		if err != nil {
+			return err
+		}
+
+		if len(changed) == 0 {
+			return errors.New("entry: No changes made")
+		}
+
+		output.Printf("Updated entry '%s' (%s)\n", changed[0].Note, changed[0].ShortHash())
+
+		return nil
+	}
+
+	return &console.Command{
+		Name:        "edit",
+		Description: "Edit a timesheet entry in your editor.",
+		Configure:   configure,
+		Execute:     execute,
+	}
+}
@@ -2,7 +2,6 @@ package entry
 
 import (
 	"errors"
-	"text/template"
 	"time"
 
 	"github.com/SeerUK/tid/pkg/tid/cli/display"
@@ -18,6 +17,8 @@ func ListCommand(factory util.Factory, config types.Config) *console.Command {
 	var date time.Time
 	var end time.Time
 	var format string
+	var outputFormat display.OutputFormat
+	var round time.Duration
 	var start time.Time
 
 	configure := func(def *console.Definition) {
@@ -39,6 +40,42 @@ func ListCommand(factory util.Factory, config types.Config) *console.Command {
 			Desc:  "Output formatting string. Uses Go templates.",
 		})
 
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.Timezone,
+			Spec:  "-z, --timezone=ZONE",
+			Desc:  "Time zone to show times in, e.g. Europe/London or local. (Default: as recorded)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.TimeFormat,
+			Spec:  "-t, --time-format=TIME_FORMAT",
+			Desc:  "Duration format: decimal, text, clock, clock-seconds, minutes, or iso8601. (Default: from config)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: &outputFormat,
+			Spec:  "-o, --output=OUTPUT",
+			Desc:  "Output format: table, markdown, or html. (Default: table)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewDurationValue(&round),
+			Spec:  "--round=INCREMENT",
+			Desc:  "Round durations to the given increment, e.g. 15m. (Default: from config)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.RoundingMode,
+			Spec:  "--round-mode=MODE",
+			Desc:  "How to round durations: nearest, up, or down. (Default: from config)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.RoundingScope,
+			Spec:  "--round-scope=SCOPE",
+			Desc:  "What to round: entry, or total. (Default: from config)",
+		})
+
 		def.AddOption(console.OptionDefinition{
 			Value: parameters.NewDateValue(&start),
 			Spec:  "-s, --start=START",
@@ -47,14 +84,19 @@ func ListCommand(factory util.Factory, config types.Config) *console.Command {
 	}
 
 	execute := func(input *console.Input, output *console.Output) error {
-		gateway := factory.BuildTrackingGateway()
+		sysGateway := factory.BuildSysGateway()
+		trGateway := factory.BuildTrackingGateway()
 
 		hasDate := input.HasOption([]string{"d", "date"})
 		hasEnd := input.HasOption([]string{"e", "end"})
 		hasFormat := input.HasOption([]string{"f", "format"})
 		hasStart := input.HasOption([]string{"s", "start"})
 
-		now := xtime.Date(time.Now())
+		if input.HasOption([]string{"round"}) {
+			config.Display.Rounding = xtime.Duration(round)
+		}
+
+		now := xtime.Date(factory.BuildClock().Now())
 
 		if !hasStart {
 			start = now
@@ -69,15 +111,34 @@ func ListCommand(factory util.Factory, config types.Config) *console.Command {
 			end = date
 		}
 
-		entries, err := gateway.FindEntriesInDateRange(start, end)
+		status, err := sysGateway.FindOrCreateStatus()
+		if err != nil {
+			return err
+		}
+
+		sheets, err := trGateway.FindTimesheetsInDateRange(start, end)
 		if err != nil {
 			return err
 		}
 
+		calendar, err := sysGateway.FindOrCreateCalendar()
+		if err != nil {
+			return err
+		}
+
+		report := types.NewReport(start, end, status.Workspace, sheets, config, calendar)
+
 		if hasFormat {
-			for _, entry := range entries {
-				tmpl := template.Must(template.New("entry-list").Parse(format))
-				tmpl.Execute(output.Writer, entry)
+			tmpl, err := display.ParseTemplate("entry-list", format, config)
+			if err != nil {
+				return err
+			}
+
+			for _, entry := range report.Entries {
+				err = tmpl.Execute(output.Writer, entry.Truncate(display.TemplatePrecision))
+				if err != nil {
+					return err
+				}
 
 				output.Println()
 			}
@@ -85,11 +146,18 @@ func ListCommand(factory util.Factory, config types.Config) *console.Command {
 			return nil
 		}
 
-		if len(entries) == 0 {
+		if report.EntryCount == 0 {
 			return errors.New("list: No entries within the given time period")
 		}
 
-		display.WriteEntriesTable(entries, output.Writer, config)
+		switch outputFormat {
+		case display.OutputMarkdown:
+			display.WriteEntriesMarkdown(report, output.Writer, config, false)
+		case display.OutputHTML:
+			return display.WriteEntriesHTML(report, output.Writer, config, false)
+		default:
+			display.WriteEntriesTable(report.Entries, output.Writer, config)
+		}
 
 		return nil
 	}
@@ -0,0 +1,48 @@
+package entry
+
+import (
+	"fmt"
+
+	"github.com/SeerUK/tid/pkg/tid/cli/display"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+)
+
+// LogCommand creates a command to show the history of changes made to a timesheet entry.
+func LogCommand(factory util.Factory, config types.Config) *console.Command {
+	var hash string
+
+	configure := func(def *console.Definition) {
+		def.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringValue(&hash),
+			Spec:  "HASH",
+			Desc:  "A short or long hash for an entry.",
+		})
+	}
+
+	execute := func(input *console.Input, output *console.Output) error {
+		entry, log, err := factory.BuildEntryFacade().Log(hash)
+		if err != nil {
+			return err
+		}
+
+		if len(log.Revisions) == 0 {
+			return fmt.Errorf("entry: No changes have been recorded for entry '%s'", entry.ShortHash())
+		}
+
+		output.Printf("Entry '%s' (%s)\n", entry.Note, entry.ShortHash())
+
+		display.WriteEntryLogTable(log, output.Writer, config)
+
+		return nil
+	}
+
+	return &console.Command{
+		Name:        "log",
+		Description: "Show who changed a timesheet entry, when, and what they changed.",
+		Configure:   configure,
+		Execute:     execute,
+	}
+}
@@ -2,17 +2,21 @@ package entry
 
 import (
 	"errors"
+	"fmt"
+	"os"
 	"time"
 
-	"github.com/SeerUK/tid/pkg/errhandling"
 	"github.com/SeerUK/tid/pkg/types"
 	"github.com/SeerUK/tid/pkg/util"
 	"github.com/eidolon/console"
 	"github.com/eidolon/console/parameters"
 )
 
-// UpdateCommand creates a command to updated timesheet entries.
-func UpdateCommand(factory util.Factory) *console.Command {
+// UpdateCommand creates a command to updated timesheet entries, either by hash, or in bulk by
+// filter. Entries are found with the given factory, and updated with a factory from the given opener
+// once the user has confirmed it, so the database isn't locked while they decide.
+func UpdateCommand(factory util.Factory, opener util.FactoryOpener, config types.Config) *console.Command {
+	var bulk bulkOptions
 	var duration time.Duration
 	var hash string
 	var offset time.Duration
@@ -21,10 +25,12 @@ func UpdateCommand(factory util.Factory) *console.Command {
 	configure := func(def *console.Definition) {
 		def.AddArgument(console.ArgumentDefinition{
 			Value: parameters.NewStringValue(&hash),
-			Spec:  "HASH",
+			Spec:  "[HASH]",
 			Desc:  "A short or long hash for an entry.",
 		})
 
+		bulk.configure(def)
+
 		def.AddOption(console.OptionDefinition{
 			Value: parameters.NewDurationValue(&duration),
 			Spec:  "-d, --duration=DURATION",
@@ -33,7 +39,7 @@ func UpdateCommand(factory util.Factory) *console.Command {
 
 		def.AddOption(console.OptionDefinition{
 			Value: parameters.NewStringValue(&note),
-			Spec:  "-n, --note=NOTE",
+			Spec:  "-n, --note, --set-note=NOTE",
 			Desc:  "A new note to set on the entry.",
 		})
 
@@ -46,42 +52,71 @@ func UpdateCommand(factory util.Factory) *console.Command {
 
 	execute := func(input *console.Input, output *console.Output) error {
 		hasDuration := input.HasOption([]string{"d", "duration"})
-		hasNote := input.HasOption([]string{"n", "note"})
+		hasNote := input.HasOption([]string{"n", "note", "set-note"})
 		hasOffset := input.HasOption([]string{"o", "offset"})
 
 		if hasDuration && hasOffset {
 			return errors.New("update: Duration and offset are mutually exclusive")
 		}
 
-		var entry types.Entry
-		var err error
+		if hash != "" && bulk.isSet(input) {
+			return errors.New("update: A hash and a filter are mutually exclusive")
+		}
+
+		if hash == "" && !bulk.isSet(input) {
+			return errors.New("update: Either a hash, or a filter is required")
+		}
+
+		update := func(entry types.Entry) types.Entry {
+			if hasDuration {
+				entry.Duration = duration
+			}
+
+			if hasOffset {
+				entry.Duration = entry.Duration + offset
+			}
 
-		errs := errhandling.NewErrorStack()
-		facade := factory.BuildEntryFacade()
+			if hasNote {
+				entry.Note = note
+			}
 
-		if hasDuration {
-			entry, err = facade.UpdateDuration(hash, duration)
-			errs.Add(err)
+			return entry
 		}
 
-		if hasOffset {
-			entry, err = facade.UpdateDurationByOffset(hash, offset)
-			errs.Add(err)
+		if hash == "" {
+			if !hasDuration && !hasNote && !hasOffset {
+				return errors.New("update: Nothing to update, use --duration, --offset, or --note")
+			}
+
+			return updateMany(factory.BuildEntryFacade(), opener, input, output, config, bulk, update)
 		}
 
-		if hasNote {
-			entry, err = facade.UpdateNote(hash, note)
-			errs.Add(err)
+		if !hasDuration && !hasNote && !hasOffset {
+			return nil
 		}
 
-		if !errs.Empty() {
-			return errs.Errors()
+		writable, release, err := opener()
+		if err != nil {
+			return err
 		}
 
-		if hasDuration || hasNote || hasOffset {
-			output.Printf("Updated entry '%s' (%s)\n", entry.Note, entry.ShortHash())
+		defer release()
+
+		entry, err := writable.BuildTrackingGateway().FindEntry(hash)
+		if err != nil {
+			return err
 		}
 
+		// Editing makes every change as one, so they're undone together.
+		edited := update(entry)
+
+		_, err = writable.BuildEntryFacade().Edit([]types.Entry{entry}, []types.Entry{edited})
+		if err != nil {
+			return err
+		}
+
+		output.Printf("Updated entry '%s' (%s)\n", edited.Note, edited.ShortHash())
+
 		return nil
 	}
 
@@ -93,3 +128,48 @@ func UpdateCommand(factory util.Factory) *console.Command {
 		Execute:     execute,
 	}
 }
+
+// updateMany applies the given update to each of the entries selected by the given bulk options,
+// once the user has confirmed it. The entries are found with the given facade, and updated with a
+// factory from the given opener.
+func updateMany(facade *util.EntryFacade, opener util.FactoryOpener, input *console.Input, output *console.Output, config types.Config, bulk bulkOptions, update func(types.Entry) types.Entry) error {
+	entries, err := bulk.find(facade, input)
+	if err != nil {
+		return err
+	}
+
+	var edited []types.Entry
+
+	for _, entry := range entries {
+		entry := update(entry)
+		if entry.Duration < 0 {
+			return fmt.Errorf("update: Duration of entry '%s' cannot be less than 0", entry.ShortHash())
+		}
+
+		edited = append(edited, entry)
+	}
+
+	confirmed, err := bulk.confirm("Update", entries, output, os.Stdin, config)
+	if err != nil || !confirmed {
+		output.Println("Cancelled, nothing was updated")
+		return err
+	}
+
+	writable, release, err := opener()
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	changed, err := writable.BuildEntryFacade().Edit(entries, edited)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range changed {
+		output.Printf("Updated entry '%s' (%s)\n", entry.Note, entry.ShortHash())
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package command
+
+import (
+	"errors"
+
+	"github.com/SeerUK/tid/pkg/tid/cli/display"
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+)
+
+// HistoryCommand creates a command to list recent changes.
+func HistoryCommand(factory util.Factory) *console.Command {
+	limit := 10
+
+	configure := func(def *console.Definition) {
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewIntValue(&limit),
+			Spec:  "-n, --limit=LIMIT",
+			Desc:  "The number of changes to show. (Default: 10)",
+		})
+	}
+
+	execute := func(input *console.Input, output *console.Output) error {
+		operations, undone, err := factory.BuildJournalFacade().History(limit)
+		if err != nil {
+			return err
+		}
+
+		if len(operations) == 0 {
+			return errors.New("history: No changes have been made")
+		}
+
+		display.WriteHistoryTable(operations, undone, output.Writer)
+
+		return nil
+	}
+
+	return &console.Command{
+		Name:        "history",
+		Alias:       "hist",
+		Description: "List recent changes, which can be undone.",
+		Configure:   configure,
+		Execute:     execute,
+	}
+}
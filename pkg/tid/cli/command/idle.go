@@ -0,0 +1,102 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/SeerUK/tid/pkg/xtime"
+	"github.com/eidolon/console"
+)
+
+// resolveIdleTimer checks if the running timer has been left running for longer than the configured
+// maximum, and if so, asks the user what to do with that time. If auto is true, or the user can't
+// be asked because stdin isn't a terminal (e.g. in scripts), the configured idle action is used
+// instead.
+func resolveIdleTimer(facade *util.TrackingFacade, config types.Config, output *console.Output, auto bool) error {
+	max := config.Tracking.MaxRunning.TimeDuration()
+
+	entry, idle, err := facade.FindIdle(max)
+	if err != nil || !idle {
+		return err
+	}
+
+	action := config.Tracking.IdleAction
+
+	if !auto && isTerminal(os.Stdin) {
+		action, err = promptIdleAction(entry, config, output, os.Stdin)
+		if err != nil {
+			return err
+		}
+	}
+
+	entry, err = facade.ResolveIdle(entry, action, max)
+	if err != nil {
+		return err
+	}
+
+	output.Printf("Applied '%s' to idle timer for '%s' (%s), duration is now %s\n",
+		action,
+		entry.Note,
+		entry.ShortHash(),
+		xtime.FormatDuration(entry.Duration, config.Display.TimeFormat),
+	)
+
+	return nil
+}
+
+// promptIdleAction asks the user what should happen to the time logged on an idle entry. If no
+// answer is given, the configured idle action is used.
+func promptIdleAction(entry types.Entry, config types.Config, output *console.Output, reader io.Reader) (types.IdleAction, error) {
+	output.Printf(
+		"Timer for '%s' (%s) has been running for %s, which is longer than %s.\n",
+		entry.Note,
+		entry.ShortHash(),
+		xtime.FormatDuration(entry.Elapsed, config.Display.TimeFormat),
+		xtime.FormatDuration(config.Tracking.MaxRunning.TimeDuration(), config.Display.TimeFormat),
+	)
+
+	output.Printf("Keep, discard, or cap this time? [keep/discard/cap] (Default: %s): ", config.Tracking.IdleAction)
+
+	answer, err := bufio.NewReader(reader).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return config.Tracking.IdleAction, err
+	}
+
+	answer = strings.TrimSpace(answer)
+
+	if err == io.EOF {
+		output.Println()
+	}
+
+	if answer == "" {
+		return config.Tracking.IdleAction, nil
+	}
+
+	for _, name := range []string{"keep", "discard", "cap"} {
+		if strings.HasPrefix(name, strings.ToLower(answer)) {
+			return types.ParseIdleAction(name)
+		}
+	}
+
+	return config.Tracking.IdleAction, fmt.Errorf("idle: Invalid answer '%s'", answer)
+}
+
+// isTerminal returns true if the given file is a terminal, and so a user can be asked questions
+// through it. The null device is a character device too, but no one can answer through it.
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+
+	if null, err := os.Stat(os.DevNull); err == nil && os.SameFile(info, null) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,27 @@
+package command
+
+import (
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/eidolon/console"
+)
+
+// RedoCommand creates a command to redo the most recently undone change.
+func RedoCommand(factory util.Factory) *console.Command {
+	execute := func(input *console.Input, output *console.Output) error {
+		operation, err := factory.BuildJournalFacade().Redo()
+		if err != nil {
+			return err
+		}
+
+		output.Printf("Redid: %s\n", operation.Description)
+		writeOperationEntries(operation, output)
+
+		return nil
+	}
+
+	return &console.Command{
+		Name:        "redo",
+		Description: "Redo the most recently undone change.",
+		Execute:     execute,
+	}
+}
@@ -2,7 +2,6 @@ package command
 
 import (
 	"errors"
-	"fmt"
 	"text/template"
 	"time"
 
@@ -21,6 +20,9 @@ func ReportCommand(factory util.Factory, config types.Config) *console.Command {
 	var format string
 	var start time.Time
 	var noSummary bool
+	var outputFormat display.OutputFormat
+	var reportTemplate string
+	var round time.Duration
 
 	configure := func(def *console.Definition) {
 		def.AddOption(console.OptionDefinition{
@@ -41,29 +43,81 @@ func ReportCommand(factory util.Factory, config types.Config) *console.Command {
 			Desc:  "Output formatting string. Uses Go templates.",
 		})
 
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.Timezone,
+			Spec:  "-z, --timezone=ZONE",
+			Desc:  "Time zone to show times in, e.g. Europe/London or local. (Default: as recorded)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.TimeFormat,
+			Spec:  "-t, --time-format=TIME_FORMAT",
+			Desc:  "Duration format: decimal, text, clock, clock-seconds, minutes, or iso8601. (Default: from config)",
+		})
+
 		def.AddOption(console.OptionDefinition{
 			Value: parameters.NewDateValue(&start),
 			Spec:  "-s, --start=START",
 			Desc:  "The start date of the report. (Default: today)",
 		})
 
+		def.AddOption(console.OptionDefinition{
+			Value: &outputFormat,
+			Spec:  "-o, --output=OUTPUT",
+			Desc:  "Output format: table, markdown, or html. (Default: table)",
+		})
+
 		def.AddOption(console.OptionDefinition{
 			Value: parameters.NewBoolValue(&noSummary),
 			Spec:  "--no-summary",
 			Desc:  "Hide the summary?",
 		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewDurationValue(&round),
+			Spec:  "--round=INCREMENT",
+			Desc:  "Round durations to the given increment, e.g. 15m. (Default: from config)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.RoundingMode,
+			Spec:  "--round-mode=MODE",
+			Desc:  "How to round durations: nearest, up, or down. (Default: from config)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.RoundingScope,
+			Spec:  "--round-scope=SCOPE",
+			Desc:  "What to round: entry, or total. (Default: from config)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&reportTemplate),
+			Spec:  "--template=TEMPLATE",
+			Desc:  "Report template string, rendered once with the whole report. Uses Go templates.",
+		})
 	}
 
 	execute := func(input *console.Input, output *console.Output) error {
-		gateway := factory.BuildTrackingGateway()
+		sysGateway := factory.BuildSysGateway()
+		trGateway := factory.BuildTrackingGateway()
 
 		hasDate := input.HasOption([]string{"d", "date"})
 		hasEnd := input.HasOption([]string{"e", "end"})
 		hasFormat := input.HasOption([]string{"f", "format"})
 		hasStart := input.HasOption([]string{"s", "start"})
+		hasTemplate := input.HasOption([]string{"template"})
+
+		if hasFormat && hasTemplate {
+			return errors.New("report: Format and template are mutually exclusive")
+		}
+
+		if input.HasOption([]string{"round"}) {
+			config.Display.Rounding = xtime.Duration(round)
+		}
 
 		// We need to get the current date, this is a little hacky, but we need it without any time
-		now := xtime.Date(time.Now())
+		now := xtime.Date(factory.BuildClock().Now())
 
 		if !hasStart {
 			start = now
@@ -78,26 +132,72 @@ func ReportCommand(factory util.Factory, config types.Config) *console.Command {
 			end = date
 		}
 
-		entries, err := gateway.FindEntriesInDateRange(start, end)
+		var tmpl *template.Template
+		var err error
+
+		switch {
+		case hasFormat:
+			tmpl, err = display.ParseTemplate("entry-list", format, config)
+		case hasTemplate:
+			tmpl, err = display.ParseTemplate("report", reportTemplate, config)
+		}
+
 		if err != nil {
 			return err
 		}
 
-		if len(entries) == 0 {
+		status, err := sysGateway.FindOrCreateStatus()
+		if err != nil {
+			return err
+		}
+
+		sheets, err := trGateway.FindTimesheetsInDateRange(start, end)
+		if err != nil {
+			return err
+		}
+
+		calendar, err := sysGateway.FindOrCreateCalendar()
+		if err != nil {
+			return err
+		}
+
+		report := types.NewReport(start, end, status.Workspace, sheets, config, calendar)
+
+		if report.EntryCount == 0 {
 			return errors.New("report: No entries within the given time period")
 		}
 
-		if !noSummary {
-			output.Printf("Report for %s.\n\n", getDateRange(start, end))
-			output.Printf("Total Duration: %s\n", getDurationForEntries(entries))
-			output.Printf("Entry Count: %d\n", len(entries))
+		if hasTemplate {
+			err = tmpl.Execute(output.Writer, report.Truncate(display.TemplatePrecision))
+			if err != nil {
+				return err
+			}
+
 			output.Println()
+
+			return nil
+		}
+
+		if !hasFormat {
+			switch outputFormat {
+			case display.OutputMarkdown:
+				display.WriteEntriesMarkdown(report, output.Writer, config, !noSummary)
+				return nil
+			case display.OutputHTML:
+				return display.WriteEntriesHTML(report, output.Writer, config, !noSummary)
+			}
+		}
+
+		if !noSummary {
+			display.WriteSummary(report, output.Writer, config)
 		}
 
 		if hasFormat {
-			for _, entry := range entries {
-				tmpl := template.Must(template.New("entry-list").Parse(format))
-				tmpl.Execute(output.Writer, entry)
+			for _, entry := range report.Entries {
+				err = tmpl.Execute(output.Writer, entry.Truncate(display.TemplatePrecision))
+				if err != nil {
+					return err
+				}
 
 				output.Println()
 			}
@@ -105,7 +205,7 @@ func ReportCommand(factory util.Factory, config types.Config) *console.Command {
 			return nil
 		}
 
-		display.WriteEntriesTable(entries, output.Writer, config)
+		display.WriteEntriesTable(report.Entries, output.Writer, config)
 
 		return nil
 	}
@@ -118,21 +218,3 @@ func ReportCommand(factory util.Factory, config types.Config) *console.Command {
 		Execute:     execute,
 	}
 }
-
-func getDateRange(start time.Time, end time.Time) string {
-	if start.Equal(end) {
-		return end.Format(xtime.DateFmt)
-	}
-
-	return fmt.Sprintf("%s to %s", start.Format(xtime.DateFmt), end.Format(xtime.DateFmt))
-}
-
-func getDurationForEntries(entries []types.Entry) time.Duration {
-	var duration time.Duration
-
-	for _, e := range entries {
-		duration = duration + e.Duration
-	}
-
-	return duration
-}
@@ -1,14 +1,21 @@
 package command
 
 import (
+	"errors"
+
+	"github.com/SeerUK/tid/pkg/types"
 	"github.com/SeerUK/tid/pkg/util"
 	"github.com/eidolon/console"
 	"github.com/eidolon/console/parameters"
 )
 
 // ResumeCommand creates a command to resume timers.
-func ResumeCommand(factory util.Factory) *console.Command {
+func ResumeCommand(factory util.Factory, config types.Config) *console.Command {
+	var auto bool
+	var fresh bool
 	var hash string
+	var match string
+	var note string
 
 	configure := func(def *console.Definition) {
 		def.AddArgument(console.ArgumentDefinition{
@@ -16,17 +23,80 @@ func ResumeCommand(factory util.Factory) *console.Command {
 			Spec:  "[HASH]",
 			Desc:  "A short or long hash for an entry.",
 		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&auto),
+			Spec:  "-a, --auto",
+			Desc:  "Resolve idle timers using the configured idle action, instead of asking.",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&fresh),
+			Spec:  "--fresh",
+			Desc:  "If the entry is from a previous day, always continue it in a new entry today.",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&match),
+			Spec:  "-m, --match=TEXT",
+			Desc:  "Resume the most recent entry with a note roughly matching the given text.",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewStringValue(&note),
+			Spec:  "-n, --note=NOTE",
+			Desc:  "Resume the most recent entry with the given note.",
+		})
 	}
 
 	execute := func(input *console.Input, output *console.Output) error {
 		facade := factory.BuildTrackingFacade()
+		boundary := config.Tracking.DayBoundary()
+
+		hasMatch := input.HasOption([]string{"m", "match"})
+		hasNote := input.HasOption([]string{"n", "note"})
+
+		if (hash != "" && (hasMatch || hasNote)) || (hasMatch && hasNote) {
+			return errors.New("resume: A hash, note, and match are mutually exclusive")
+		}
 
-		_, err := facade.Stop()
+		if (hasMatch && match == "") || (hasNote && note == "") {
+			return errors.New("resume: The note to find must not be empty")
+		}
+
+		err := resolveIdleTimer(facade, config, output, auto)
+		if err != nil {
+			return err
+		}
+
+		var entry types.Entry
+
+		switch {
+		case hasNote:
+			entry, err = facade.FindLatestByNote(note, false)
+		case hasMatch:
+			entry, err = facade.FindLatestByNote(match, true)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if entry.Hash != "" {
+			hash = entry.Hash
+		}
+
+		_, err = facade.Stop(boundary)
 		if err != nil && err != util.ErrNoTimerRunning {
 			return err
 		}
 
-		entry, err := facade.Resume(hash)
+		if fresh {
+			entry, err = facade.ResumeFresh(hash, boundary)
+		} else {
+			entry, err = facade.Resume(hash, boundary, config.Tracking.Resume)
+		}
+
 		if err != nil {
 			return err
 		}
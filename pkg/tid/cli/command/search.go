@@ -0,0 +1,74 @@
+package command
+
+import (
+	"errors"
+
+	"github.com/SeerUK/tid/pkg/tid/cli/display"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+)
+
+// SearchCommand creates a command to search for entries by their notes.
+func SearchCommand(factory util.Factory, config types.Config) *console.Command {
+	var all bool
+	var ignoreCase bool
+	var query string
+	var regex bool
+
+	configure := func(def *console.Definition) {
+		def.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewStringValue(&query),
+			Spec:  "QUERY",
+			Desc:  "The text to search entry notes for.",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&all),
+			Spec:  "-a, --all-workspaces",
+			Desc:  "Search every workspace, instead of just the current one.",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&ignoreCase),
+			Spec:  "-i, --ignore-case",
+			Desc:  "Match notes regardless of case.",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&regex),
+			Spec:  "-r, --regex",
+			Desc:  "Treat the query as a regular expression, instead of text.",
+		})
+	}
+
+	execute := func(input *console.Input, output *console.Output) error {
+		searchQuery, err := types.NewSearchQuery(query, regex, ignoreCase)
+		if err != nil {
+			return err
+		}
+
+		results, err := factory.BuildSearchFacade().Search(searchQuery, all)
+		if err != nil {
+			return err
+		}
+
+		if len(results) == 0 {
+			return errors.New("search: No entries match the given query")
+		}
+
+		display.WriteSearchTable(results, all, output.Writer, config)
+		output.Println()
+		display.WriteSearchGroupsTable(types.NewSearchGroups(results), output.Writer, config)
+
+		return nil
+	}
+
+	return &console.Command{
+		Name:        "search",
+		Description: "Search for entries by their notes, across all time.",
+		Configure:   configure,
+		Execute:     execute,
+	}
+}
@@ -1,14 +1,20 @@
 package command
 
 import (
+	"errors"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/types"
 	"github.com/SeerUK/tid/pkg/util"
 	"github.com/eidolon/console"
 	"github.com/eidolon/console/parameters"
 )
 
 // StartCommand creates a command to start timers.
-func StartCommand(factory util.Factory) *console.Command {
+func StartCommand(factory util.Factory, config types.Config) *console.Command {
 	var note string
+	var planned time.Duration
+	var plannedBreak time.Duration
 
 	configure := func(def *console.Definition) {
 		def.AddArgument(console.ArgumentDefinition{
@@ -16,16 +22,41 @@ func StartCommand(factory util.Factory) *console.Command {
 			Spec:  "NOTE",
 			Desc:  "What are you working on?",
 		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewDurationValue(&plannedBreak),
+			Spec:  "-b, --break=DURATION",
+			Desc:  "A break to record once the timebox has ended, e.g. 5m. Requires --for.",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewDurationValue(&planned),
+			Spec:  "--for=DURATION",
+			Desc:  "Timebox the timer, stopping it once it has run for the given duration, e.g. 25m.",
+		})
 	}
 
 	execute := func(input *console.Input, output *console.Output) error {
 		facade := factory.BuildTrackingFacade()
 
-		entry, err := facade.Start(note)
+		if planned < 0 || plannedBreak < 0 {
+			return errors.New("start: Timebox and break durations must not be negative")
+		}
+
+		if plannedBreak > 0 && planned == 0 {
+			return errors.New("start: A break can only be recorded after a timebox, use --for")
+		}
+
+		entry, err := facade.Start(note, planned, plannedBreak, config.Tracking.DayBoundary())
 		if err != nil {
 			return err
 		}
 
+		if entry.IsTimeboxed() {
+			output.Printf("Started timer for '%s' (%s) for %s\n", entry.Note, entry.ShortHash(), entry.Planned)
+			return nil
+		}
+
 		output.Printf("Started timer for '%s' (%s)\n", entry.Note, entry.ShortHash())
 
 		return nil
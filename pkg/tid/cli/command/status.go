@@ -1,22 +1,55 @@
 package command
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
 	"text/template"
+	"time"
 
 	"github.com/SeerUK/tid/pkg/state"
 	"github.com/SeerUK/tid/pkg/tid/cli/display"
 	"github.com/SeerUK/tid/pkg/types"
 	"github.com/SeerUK/tid/pkg/util"
+	"github.com/SeerUK/tid/pkg/xtime"
 	"github.com/eidolon/console"
 	"github.com/eidolon/console/parameters"
 )
 
+const (
+	// StatusExitRunning is the exit code of a short status when the timer is running.
+	StatusExitRunning = 0
+	// StatusExitNoTimer is the exit code of a short status when there is no timer.
+	StatusExitNoTimer = 2
+	// StatusExitStopped is the exit code of a short status when the timer is stopped. It's not 1,
+	// as that's the exit code used when tid fails, e.g. because the database is busy.
+	StatusExitStopped = 3
+)
+
+// porcelainEscaper escapes characters in notes that would break the one line per status format of
+// porcelain output.
+var porcelainEscaper = strings.NewReplacer("\\", "\\\\", "\n", "\\n", "\r", "\\r")
+
+const (
+	// clearScreen is the ANSI escape sequence to move the cursor home, and clear the screen.
+	clearScreen = "\033[H\033[2J"
+	// clearLine is the ANSI escape sequence to move the cursor to the start of the line, and clear it.
+	clearLine = "\r\033[K"
+)
+
 // StatusCommand creates a command to view the status of the current timer.
-func StatusCommand(factory util.Factory, config types.Config) *console.Command {
+func StatusCommand(factory util.Factory, config types.Config, backend state.Backend, connector state.Connector) *console.Command {
+	var compact bool
 	var format string
 	var hash string
+	var interval time.Duration
+	var porcelain bool
+	var short bool
+	var watch bool
 
 	configure := func(def *console.Definition) {
 		def.AddArgument(console.ArgumentDefinition{
@@ -25,53 +58,118 @@ func StatusCommand(factory util.Factory, config types.Config) *console.Command {
 			Desc:  "A short or long hash for an entry.",
 		})
 
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&compact),
+			Spec:  "-c, --compact",
+			Desc:  "Show the status on a single line.",
+		})
+
 		def.AddOption(console.OptionDefinition{
 			Value: parameters.NewStringValue(&format),
 			Spec:  "-f, --format=FORMAT",
 			Desc:  "Output formatting string. Uses Go templates.",
 		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewDurationValue(&interval),
+			Spec:  "-i, --interval=INTERVAL",
+			Desc:  "How often to redraw the status when watching. (Default: 1s)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&porcelain),
+			Spec:  "--porcelain",
+			Desc:  "Show the status in a stable, machine-readable format.",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&short),
+			Spec:  "-s, --short",
+			Desc:  "Show the status briefly, e.g. for a shell prompt.",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.TimeFormat,
+			Spec:  "-t, --time-format=TIME_FORMAT",
+			Desc:  "Duration format: decimal, text, clock, clock-seconds, minutes, or iso8601. (Default: from config)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&watch),
+			Spec:  "-w, --watch",
+			Desc:  "Keep redrawing the status until interrupted.",
+		})
 	}
 
 	execute := func(input *console.Input, output *console.Output) error {
-		sysGateway := factory.BuildSysGateway()
-		trGateway := factory.BuildTrackingGateway()
+		var tmpl *template.Template
+		var err error
 
-		hasFormat := input.HasOption([]string{"f", "format"})
+		if short || porcelain {
+			if !input.HasOption([]string{"t", "time-format"}) {
+				config.Display.TimeFormat = xtime.FormatClock
+			}
 
-		status, err := sysGateway.FindOrCreateStatus()
-		if err != nil {
-			return err
+			code, err := writeStatusLine(factory, config, hash, porcelain, output.Writer)
+			if err != nil {
+				return err
+			}
+
+			output.SetExitCode(code)
+
+			return nil
 		}
 
-		if hash == "" {
-			hash = status.Entry
+		if input.HasOption([]string{"f", "format"}) {
+			tmpl, err = display.ParseTemplate("entry-status", format, config)
+			if err != nil {
+				return err
+			}
 		}
 
-		if hash == "" {
-			return errors.New("status: No timer to check the status of")
+		if !watch {
+			return writeStatus(factory, config, hash, tmpl, compact, output.Writer)
 		}
 
-		entry, err := trGateway.FindEntry(hash)
-		if err != nil && err != state.ErrStoreNilResult {
-			return err
+		if interval <= 0 {
+			interval = time.Second
 		}
 
-		if err == state.ErrStoreNilResult {
-			return fmt.Errorf("status: No entry with hash '%s'", hash)
+		// Release the writable database, each redraw opens it read-only for as short a time as
+		// possible instead, so that other tid processes aren't blocked while we're watching.
+		err = backend.Close()
+		if err != nil {
+			return err
 		}
 
-		if hasFormat {
-			tmpl := template.Must(template.New("entry-list").Parse(format))
-			tmpl.Execute(output.Writer, entry)
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		defer signal.Stop(interrupt)
 
-			output.Println()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
 
-			return nil
-		}
+		for {
+			var buf bytes.Buffer
 
-		display.WriteEntriesTable([]types.Entry{entry}, output.Writer, config)
+			err = watchStatus(connector, factory.BuildClock(), config, hash, tmpl, compact, &buf)
+			if err != nil {
+				fmt.Fprintln(&buf, err)
+			}
 
-		return nil
+			if compact {
+				output.Print(clearLine + string(bytes.TrimRight(buf.Bytes(), "\n")))
+			} else {
+				output.Print(clearScreen + buf.String())
+			}
+
+			select {
+			case <-interrupt:
+				output.Println()
+				return nil
+			case <-ticker.C:
+			}
+		}
 	}
 
 	return &console.Command{
@@ -82,3 +180,158 @@ func StatusCommand(factory util.Factory, config types.Config) *console.Command {
 		Execute:     execute,
 	}
 }
+
+// writeStatusLine writes the status of the entry with the given hash, or the current entry if no
+// hash is given, on a single line. Porcelain output is intended to be parsed, and is of the form
+// "<running|stopped|none> <short hash> <duration in seconds> <timesheet date> <note>", with
+// backslashes and line breaks in the note escaped. The returned exit code shows whether the timer is
+// running, stopped, or whether there is no timer.
+func writeStatusLine(factory util.Factory, config types.Config, hash string, porcelain bool, writer io.Writer) (int, error) {
+	sysGateway := factory.BuildSysGateway()
+	trGateway := factory.BuildTrackingGateway()
+
+	status, err := sysGateway.FindOrCreateStatus()
+	if err != nil {
+		return 0, err
+	}
+
+	if hash == "" {
+		hash = status.Entry
+	}
+
+	if hash == "" {
+		if porcelain {
+			fmt.Fprintln(writer, "none")
+		}
+
+		return StatusExitNoTimer, nil
+	}
+
+	entry, err := trGateway.FindEntry(hash)
+	if err == state.ErrStoreNilResult {
+		return 0, fmt.Errorf("status: No entry with hash '%s'", hash)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	code := StatusExitStopped
+	if entry.IsRunning {
+		code = StatusExitRunning
+	}
+
+	if porcelain {
+		running := "stopped"
+		if entry.IsRunning {
+			running = "running"
+		}
+
+		fmt.Fprintf(
+			writer,
+			"%s %s %d %s %s\n",
+			running,
+			entry.ShortHash(),
+			int64(entry.Duration.Seconds()),
+			entry.Timesheet,
+			porcelainEscaper.Replace(entry.Note),
+		)
+
+		return code, nil
+	}
+
+	symbol := "○"
+	if entry.IsRunning {
+		symbol = "●"
+	}
+
+	fmt.Fprintf(
+		writer,
+		"%s %s %s %s\n",
+		symbol,
+		entry.ShortHash(),
+		xtime.FormatDuration(entry.Duration, config.Display.TimeFormat),
+		entry.Note,
+	)
+
+	return code, nil
+}
+
+// watchStatus writes the status of an entry using a short-lived, read-only connection.
+func watchStatus(connector state.Connector, clock xtime.Clock, config types.Config, hash string, tmpl *template.Template, compact bool, writer io.Writer) error {
+	backend, err := connector.OpenReadOnly()
+	if err != nil {
+		return err
+	}
+
+	defer backend.Close()
+
+	return writeStatus(util.NewStandardFactory(backend, clock), config, hash, tmpl, compact, writer)
+}
+
+// writeStatus writes the status of the entry with the given hash, or the current entry if no hash
+// is given, using the given template if there is one.
+func writeStatus(factory util.Factory, config types.Config, hash string, tmpl *template.Template, compact bool, writer io.Writer) error {
+	sysGateway := factory.BuildSysGateway()
+	trGateway := factory.BuildTrackingGateway()
+
+	status, err := sysGateway.FindOrCreateStatus()
+	if err != nil {
+		return err
+	}
+
+	if hash == "" {
+		hash = status.Entry
+	}
+
+	if hash == "" {
+		return errors.New("status: No timer to check the status of")
+	}
+
+	entry, err := trGateway.FindEntry(hash)
+	if err != nil && err != state.ErrStoreNilResult {
+		return err
+	}
+
+	if err == state.ErrStoreNilResult {
+		return fmt.Errorf("status: No entry with hash '%s'", hash)
+	}
+
+	if tmpl != nil {
+		err = tmpl.Execute(writer, entry.Truncate(display.TemplatePrecision))
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(writer)
+
+		return nil
+	}
+
+	if compact {
+		display.WriteEntryLine(entry, writer, config)
+		return nil
+	}
+
+	display.WriteEntriesTable([]types.Entry{entry}, writer, config)
+
+	if entry.IsTimeboxed() {
+		fmt.Fprintf(
+			writer,
+			"Timeboxed for %s, %s remaining.\n",
+			xtime.FormatDuration(entry.Planned, config.Display.TimeFormat),
+			xtime.FormatDuration(entry.Remaining(), config.Display.TimeFormat),
+		)
+	}
+
+	if entry.IsIdle(config.Tracking.MaxRunning.TimeDuration()) {
+		fmt.Fprintf(
+			writer,
+			"Warning: This timer has been running for %s without being stopped, which is longer than %s.\n",
+			xtime.FormatDuration(entry.Elapsed, config.Display.TimeFormat),
+			xtime.FormatDuration(config.Tracking.MaxRunning.TimeDuration(), config.Display.TimeFormat),
+		)
+	}
+
+	return nil
+}
@@ -1,16 +1,33 @@
 package command
 
 import (
+	"github.com/SeerUK/tid/pkg/types"
 	"github.com/SeerUK/tid/pkg/util"
 	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
 )
 
 // StopCommand creates a command to stop timers.
-func StopCommand(factory util.Factory) *console.Command {
+func StopCommand(factory util.Factory, config types.Config) *console.Command {
+	var auto bool
+
+	configure := func(def *console.Definition) {
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewBoolValue(&auto),
+			Spec:  "-a, --auto",
+			Desc:  "Resolve idle timers using the configured idle action, instead of asking.",
+		})
+	}
+
 	execute := func(input *console.Input, output *console.Output) error {
 		facade := factory.BuildTrackingFacade()
 
-		entry, err := facade.Stop()
+		err := resolveIdleTimer(facade, config, output, auto)
+		if err != nil {
+			return err
+		}
+
+		entry, err := facade.Stop(config.Tracking.DayBoundary())
 		if err != nil {
 			return err
 		}
@@ -23,6 +40,7 @@ func StopCommand(factory util.Factory) *console.Command {
 	return &console.Command{
 		Name:        "stop",
 		Description: "Stop the current timer.",
+		Configure:   configure,
 		Execute:     execute,
 	}
 }
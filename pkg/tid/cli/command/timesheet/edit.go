@@ -0,0 +1,77 @@
+package timesheet
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/tid/cli/editor"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/eidolon/console"
+	"github.com/eidolon/console/parameters"
+)
+
+// EditCommand creates a command to edit the entries on a timesheet in the user's editor. The entries
+// are read with the given factory, and the changes are made with a factory from the given opener
+// once the editor exits, so the database isn't locked while the editor is open.
+func EditCommand(factory util.Factory, opener util.FactoryOpener) *console.Command {
+	var date time.Time
+
+	configure := func(def *console.Definition) {
+		def.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewDateValue(&date),
+			Spec:  "DATE",
+			Desc:  "The date of the timesheet.",
+		})
+	}
+
+	execute := func(input *console.Input, output *console.Output) error {
+		sheet, err := factory.BuildTrackingGateway().FindTimesheet(date.Format(types.TimesheetKeyDateFmt))
+		if err != nil {
+			return err
+		}
+
+		if len(sheet.Entries) == 0 {
+			return fmt.Errorf("timesheet: There are no entries on timesheet '%s'", sheet.Key)
+		}
+
+		edited, err := editor.EditEntries(sheet.Entries)
+		if err != nil {
+			return err
+		}
+
+		if len(edited) == 0 {
+			return errors.New("timesheet: No changes made")
+		}
+
+		writable, release, err := opener()
+		if err != nil {
+			return err
+		}
+
+		defer release()
+
+		changed, err := writable.BuildEntryFacade().Edit(sheet.Entries, edited)
+		if err != nil {
+			return err
+		}
+
+		if len(changed) == 0 {
+			return errors.New("timesheet: No changes made")
+		}
+
+		for _, entry := range changed {
+			output.Printf("Updated entry '%s' (%s)\n", entry.Note, entry.ShortHash())
+		}
+
+		return nil
+	}
+
+	return &console.Command{
+		Name:        "edit",
+		Description: "Edit the entries on a timesheet in your editor.",
+		Configure:   configure,
+		Execute:     execute,
+	}
+}
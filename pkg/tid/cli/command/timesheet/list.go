@@ -2,7 +2,6 @@ package timesheet
 
 import (
 	"errors"
-	"text/template"
 	"time"
 
 	"github.com/SeerUK/tid/pkg/tid/cli/display"
@@ -17,6 +16,8 @@ import (
 func ListCommand(factory util.Factory, config types.Config) *console.Command {
 	var end time.Time
 	var format string
+	var outputFormat display.OutputFormat
+	var round time.Duration
 	var start time.Time
 
 	configure := func(def *console.Definition) {
@@ -32,6 +33,42 @@ func ListCommand(factory util.Factory, config types.Config) *console.Command {
 			Desc:  "Output formatting string. Uses Go templates.",
 		})
 
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.Timezone,
+			Spec:  "-z, --timezone=ZONE",
+			Desc:  "Time zone to show times in, e.g. Europe/London or local. (Default: as recorded)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.TimeFormat,
+			Spec:  "-t, --time-format=TIME_FORMAT",
+			Desc:  "Duration format: decimal, text, clock, clock-seconds, minutes, or iso8601. (Default: from config)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: &outputFormat,
+			Spec:  "-o, --output=OUTPUT",
+			Desc:  "Output format: table, markdown, or html. (Default: table)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: parameters.NewDurationValue(&round),
+			Spec:  "--round=INCREMENT",
+			Desc:  "Round durations to the given increment, e.g. 15m. (Default: from config)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.RoundingMode,
+			Spec:  "--round-mode=MODE",
+			Desc:  "How to round durations: nearest, up, or down. (Default: from config)",
+		})
+
+		def.AddOption(console.OptionDefinition{
+			Value: &config.Display.RoundingScope,
+			Spec:  "--round-scope=SCOPE",
+			Desc:  "What to round: entry, or total. (Default: from config)",
+		})
+
 		def.AddOption(console.OptionDefinition{
 			Value: parameters.NewDateValue(&start),
 			Spec:  "-s, --start=START",
@@ -40,16 +77,21 @@ func ListCommand(factory util.Factory, config types.Config) *console.Command {
 	}
 
 	execute := func(input *console.Input, output *console.Output) error {
+		sysGateway := factory.BuildSysGateway()
 		trGateway := factory.BuildTrackingGateway()
 
 		hasEnd := input.HasOption([]string{"e", "end"})
 		hasFormat := input.HasOption([]string{"f", "format"})
 		hasStart := input.HasOption([]string{"s", "start"})
 
-		now := xtime.Date(time.Now())
+		if input.HasOption([]string{"round"}) {
+			config.Display.Rounding = xtime.Duration(round)
+		}
+
+		now := xtime.Date(factory.BuildClock().Now())
 
 		if !hasStart {
-			start = xtime.LastWeekday(config.Display.FirstWeekday.TimeWeekday())
+			start = xtime.LastWeekday(now, config.Display.FirstWeekday.TimeWeekday())
 		}
 
 		if !hasEnd {
@@ -63,9 +105,16 @@ func ListCommand(factory util.Factory, config types.Config) *console.Command {
 		}
 
 		if hasFormat {
+			tmpl, err := display.ParseTemplate("timesheet-list", format, config)
+			if err != nil {
+				return err
+			}
+
 			for _, t := range ts {
-				tmpl := template.Must(template.New("entry-list").Parse(format))
-				tmpl.Execute(output.Writer, t)
+				err = tmpl.Execute(output.Writer, t.Truncate(display.TemplatePrecision))
+				if err != nil {
+					return err
+				}
 
 				output.Println()
 			}
@@ -77,7 +126,26 @@ func ListCommand(factory util.Factory, config types.Config) *console.Command {
 			return errors.New("list: No timesheets within the given time period")
 		}
 
-		display.WriteTimesheetsTable(ts, output.Writer, config)
+		status, err := sysGateway.FindOrCreateStatus()
+		if err != nil {
+			return err
+		}
+
+		calendar, err := sysGateway.FindOrCreateCalendar()
+		if err != nil {
+			return err
+		}
+
+		report := types.NewReport(start, end, status.Workspace, ts, config, calendar)
+
+		switch outputFormat {
+		case display.OutputMarkdown:
+			display.WriteTimesheetsMarkdown(report, output.Writer, config, false)
+		case display.OutputHTML:
+			return display.WriteTimesheetsHTML(report, output.Writer, config, false)
+		default:
+			display.WriteTimesheetsTable(report, output.Writer, config)
+		}
 
 		return nil
 	}
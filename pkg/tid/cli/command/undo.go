@@ -0,0 +1,35 @@
+package command
+
+import (
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/eidolon/console"
+)
+
+// UndoCommand creates a command to undo the most recent change.
+func UndoCommand(factory util.Factory) *console.Command {
+	execute := func(input *console.Input, output *console.Output) error {
+		operation, err := factory.BuildJournalFacade().Undo()
+		if err != nil {
+			return err
+		}
+
+		output.Printf("Undid: %s\n", operation.Description)
+		writeOperationEntries(operation, output)
+
+		return nil
+	}
+
+	return &console.Command{
+		Name:        "undo",
+		Description: "Undo the most recent change.",
+		Execute:     execute,
+	}
+}
+
+// writeOperationEntries writes a line for each of the entries changed by the given operation.
+func writeOperationEntries(operation types.JournalOperation, output *console.Output) {
+	for _, entry := range operation.Entries {
+		output.Printf("  %s %s\n", entry.ShortHash(), entry.Note)
+	}
+}
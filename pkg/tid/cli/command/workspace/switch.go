@@ -1,13 +1,14 @@
 package workspace
 
 import (
+	"github.com/SeerUK/tid/pkg/types"
 	"github.com/SeerUK/tid/pkg/util"
 	"github.com/eidolon/console"
 	"github.com/eidolon/console/parameters"
 )
 
 // SwitchCommand create a command to switch workspaces.
-func SwitchCommand(factory util.Factory) *console.Command {
+func SwitchCommand(factory util.Factory, config types.Config) *console.Command {
 	var name string
 
 	configure := func(def *console.Definition) {
@@ -22,7 +23,7 @@ func SwitchCommand(factory util.Factory) *console.Command {
 		trFacade := factory.BuildTrackingFacade()
 		wsFacade := factory.BuildWorkspaceFacade()
 
-		_, err := trFacade.Stop()
+		_, err := trFacade.Stop(config.Tracking.DayBoundary())
 		if err != nil && err != util.ErrNoTimerRunning {
 			return err
 		}
@@ -0,0 +1,133 @@
+package display
+
+import (
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+// htmlTemplate is the template used to render self-contained HTML documents. It defines an
+// "entries" and a "timesheets" template, both of which are passed an htmlData.
+const htmlTemplate = `
+{{- define "header" -}}
+<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Report for {{.Report.DateRange}}</title>
+<style>
+body { font-family: -apple-system, "Helvetica Neue", Arial, sans-serif; color: #222; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.8em; text-align: left; }
+th { background: #f4f4f4; }
+tfoot td { font-weight: bold; }
+</style>
+</head>
+<body>
+{{- if .Summary}}
+<h1>Report for {{.Report.DateRange}}</h1>
+<ul>
+<li><strong>Workspace:</strong> {{.Report.Workspace}}</li>
+<li><strong>Total Duration:</strong> {{duration .Report.Duration}}</li>
+<li><strong>Entry Count:</strong> {{.Report.EntryCount}}</li>
+{{- if .Report.Target}}
+<li><strong>Target:</strong> {{duration .Report.Target}}</li>
+<li><strong>Remaining:</strong> {{duration .Report.Remaining}}</li>
+<li><strong>Overtime:</strong> {{duration .Report.Overtime}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+
+{{- define "footer"}}
+</body>
+</html>
+{{end}}
+
+{{- define "entries"}}
+{{- template "header" .}}
+{{- range .Report.Timesheets}}
+<h2>{{.Key}}</h2>
+<table>
+<thead>
+<tr><th>Hash</th><th>Created</th><th>Updated</th><th>Note</th><th>Duration</th><th>Running</th></tr>
+</thead>
+<tbody>
+{{- range .Entries}}
+<tr><td>{{.ShortHash}}</td><td>{{.Created.Format .CreatedTimeFormat}}</td><td>{{.Updated.Format .UpdatedTimeFormat}}</td><td>{{.Note}}</td><td>{{entryDuration .Duration}}</td><td>{{.IsRunning}}</td></tr>
+{{- end}}
+</tbody>
+<tfoot>
+<tr><td colspan="4">Subtotal</td><td colspan="2">{{duration .Duration}}</td></tr>
+</tfoot>
+</table>
+{{- end}}
+{{- template "footer" .}}
+{{- end}}
+
+{{- define "timesheets"}}
+{{- template "header" .}}
+<table>
+<thead>
+<tr><th>Date</th><th>Entries</th><th>Duration</th>
+{{- if .Report.Target}}<th>Target</th><th>Remaining</th><th>Overtime</th>{{end}}</tr>
+</thead>
+<tbody>
+{{- $hasTargets := .Report.Target}}
+{{- range .Report.Timesheets}}
+<tr><td>{{.Label}}</td><td>{{.EntryCount}}</td><td>{{duration .Duration}}</td>
+{{- if $hasTargets}}<td>{{duration .Target}}</td><td>{{duration .Remaining}}</td><td>{{duration .Overtime}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+<tfoot>
+<tr><td>TOTAL</td><td>{{.Report.EntryCount}}</td><td>{{duration .Report.Duration}}</td>
+{{- if .Report.Target}}<td>{{duration .Report.Target}}</td><td>{{duration .Report.Remaining}}</td><td>{{duration .Report.Overtime}}</td>{{end}}</tr>
+</tfoot>
+</table>
+{{- template "footer" .}}
+{{- end}}
+`
+
+// htmlData is the data passed to the HTML templates.
+type htmlData struct {
+	// The report to render.
+	Report types.Report
+	// Whether or not to render the summary block.
+	Summary bool
+}
+
+// WriteEntriesHTML writes the entries in the given report to a writer as a self-contained HTML
+// document, with a table of entries and a subtotal for each timesheet. The summary block is written
+// if summary is true.
+func WriteEntriesHTML(report types.Report, writer io.Writer, config types.Config, summary bool) error {
+	return createHTMLTemplate(config).ExecuteTemplate(writer, "entries", htmlData{
+		Report:  report,
+		Summary: summary,
+	})
+}
+
+// WriteTimesheetsHTML writes the timesheets in the given report to a writer as a self-contained
+// HTML document, with a total. The summary block is written if summary is true.
+func WriteTimesheetsHTML(report types.Report, writer io.Writer, config types.Config, summary bool) error {
+	return createHTMLTemplate(config).ExecuteTemplate(writer, "timesheets", htmlData{
+		Report:  report,
+		Summary: summary,
+	})
+}
+
+// createHTMLTemplate creates the HTML template, with functions that respect the given config.
+func createHTMLTemplate(config types.Config) *template.Template {
+	funcs := template.FuncMap{
+		"duration": func(duration time.Duration) string {
+			return xtime.FormatDuration(duration, config.Display.TimeFormat)
+		},
+		"entryDuration": func(duration time.Duration) string {
+			return xtime.FormatDuration(config.Display.RoundEntryDuration(duration), config.Display.TimeFormat)
+		},
+	}
+
+	return template.Must(template.New("html").Funcs(funcs).Parse(htmlTemplate))
+}
@@ -0,0 +1,69 @@
+package display_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/SeerUK/tid/pkg/tid/cli/display"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+func TestWriteEntriesHTML(t *testing.T) {
+	config := types.NewConfig()
+	config.Display.TimeFormat = xtime.FormatClock
+
+	var out bytes.Buffer
+
+	if err := display.WriteEntriesHTML(newTestReport(config), &out, config, true); err != nil {
+		t.Fatalf("unexpected error writing HTML: %v", err)
+	}
+
+	expected := []string{
+		"<!DOCTYPE html>",
+		"<h1>Report for 2017-03-01</h1>",
+		"<li><strong>Total Duration:</strong> 1:30</li>",
+		"<h2>2017-03-01</h2>",
+		"<tr><td>abc1234</td><td>9:00:00AM</td><td>9:00:00AM</td><td>Plain</td><td>1:00</td><td>false</td></tr>",
+		"<td>A | B\n&lt;b&gt;&amp;&lt;/b&gt;</td>",
+		"<tr><td colspan=\"4\">Subtotal</td><td colspan=\"2\">1:30</td></tr>",
+		"</html>",
+	}
+
+	for _, fragment := range expected {
+		if !strings.Contains(out.String(), fragment) {
+			t.Errorf("expected output to contain %q, got:\n%s", fragment, out.String())
+		}
+	}
+
+	if strings.Contains(out.String(), "<b>&</b>") {
+		t.Errorf("expected notes to be escaped, got:\n%s", out.String())
+	}
+}
+
+func TestWriteTimesheetsHTML(t *testing.T) {
+	config := types.NewConfig()
+	config.Display.TimeFormat = xtime.FormatClock
+
+	var out bytes.Buffer
+
+	if err := display.WriteTimesheetsHTML(newTestReport(config), &out, config, false); err != nil {
+		t.Fatalf("unexpected error writing HTML: %v", err)
+	}
+
+	expected := []string{
+		"<tr><td>2017-03-01</td><td>2</td><td>1:30</td></tr>",
+		"<tr><td>TOTAL</td><td>2</td><td>1:30</td></tr>",
+	}
+
+	for _, fragment := range expected {
+		if !strings.Contains(out.String(), fragment) {
+			t.Errorf("expected output to contain %q, got:\n%s", fragment, out.String())
+		}
+	}
+
+	if strings.Contains(out.String(), "<h1>") || strings.Contains(out.String(), "<th>Target</th>") {
+		t.Errorf("expected no summary or target columns, got:\n%s", out.String())
+	}
+}
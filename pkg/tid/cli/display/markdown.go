@@ -0,0 +1,121 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+// markdownEscaper escapes characters in user-provided text that would break Markdown tables.
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\n", " ")
+
+// WriteEntriesMarkdown writes the entries in the given report to a writer as Markdown, with a table
+// of entries and a subtotal for each timesheet. The summary block is written if summary is true.
+func WriteEntriesMarkdown(report types.Report, writer io.Writer, config types.Config, summary bool) {
+	if summary {
+		writeMarkdownSummary(report, writer, config)
+	}
+
+	for _, sheet := range report.Timesheets {
+		fmt.Fprintf(writer, "## %s\n\n", sheet.Key)
+		fmt.Fprintln(writer, "| Hash | Created | Updated | Note | Duration | Running |")
+		fmt.Fprintln(writer, "|------|---------|---------|------|----------|---------|")
+
+		for _, entry := range sheet.Entries {
+			fmt.Fprintf(
+				writer,
+				"| %s | %s | %s | %s | %s | %t |\n",
+				entry.ShortHash(),
+				entry.Created.Format(entry.CreatedTimeFormat()),
+				entry.Updated.Format(entry.UpdatedTimeFormat()),
+				markdownEscaper.Replace(entry.Note),
+				xtime.FormatDuration(config.Display.RoundEntryDuration(entry.Duration), config.Display.TimeFormat),
+				entry.IsRunning,
+			)
+		}
+
+		fmt.Fprintf(
+			writer,
+			"| | | | **Subtotal** | **%s** | |\n\n",
+			xtime.FormatDuration(sheet.Duration, config.Display.TimeFormat),
+		)
+	}
+}
+
+// WriteTimesheetsMarkdown writes the timesheets in the given report to a writer as a Markdown
+// table, with a total. The summary block is written if summary is true.
+func WriteTimesheetsMarkdown(report types.Report, writer io.Writer, config types.Config, summary bool) {
+	if summary {
+		writeMarkdownSummary(report, writer, config)
+	}
+
+	hasTargets := report.Target > 0
+
+	if hasTargets {
+		fmt.Fprintln(writer, "| Date | Entries | Duration | Target | Remaining | Overtime |")
+		fmt.Fprintln(writer, "|------|---------|----------|--------|-----------|----------|")
+	} else {
+		fmt.Fprintln(writer, "| Date | Entries | Duration |")
+		fmt.Fprintln(writer, "|------|---------|----------|")
+	}
+
+	for _, sheet := range report.Timesheets {
+		fmt.Fprintf(
+			writer,
+			"| %s | %d | %s |",
+			markdownEscaper.Replace(sheet.Label()),
+			sheet.EntryCount,
+			xtime.FormatDuration(sheet.Duration, config.Display.TimeFormat),
+		)
+
+		if hasTargets {
+			fmt.Fprintf(
+				writer,
+				" %s | %s | %s |",
+				xtime.FormatDuration(sheet.Target, config.Display.TimeFormat),
+				xtime.FormatDuration(sheet.Remaining(), config.Display.TimeFormat),
+				xtime.FormatDuration(sheet.Overtime(), config.Display.TimeFormat),
+			)
+		}
+
+		fmt.Fprintln(writer)
+	}
+
+	fmt.Fprintf(
+		writer,
+		"| **TOTAL** | **%d** | **%s** |",
+		report.EntryCount,
+		xtime.FormatDuration(report.Duration, config.Display.TimeFormat),
+	)
+
+	if hasTargets {
+		fmt.Fprintf(
+			writer,
+			" **%s** | **%s** | **%s** |",
+			xtime.FormatDuration(report.Target, config.Display.TimeFormat),
+			xtime.FormatDuration(report.Remaining(), config.Display.TimeFormat),
+			xtime.FormatDuration(report.Overtime(), config.Display.TimeFormat),
+		)
+	}
+
+	fmt.Fprintln(writer)
+}
+
+// writeMarkdownSummary writes the summary block of a report as Markdown.
+func writeMarkdownSummary(report types.Report, writer io.Writer, config types.Config) {
+	fmt.Fprintf(writer, "# Report for %s\n\n", report.DateRange())
+	fmt.Fprintf(writer, "* **Workspace:** %s\n", markdownEscaper.Replace(report.Workspace))
+	fmt.Fprintf(writer, "* **Total Duration:** %s\n", xtime.FormatDuration(report.Duration, config.Display.TimeFormat))
+	fmt.Fprintf(writer, "* **Entry Count:** %d\n", report.EntryCount)
+
+	if report.Target > 0 {
+		fmt.Fprintf(writer, "* **Target:** %s\n", xtime.FormatDuration(report.Target, config.Display.TimeFormat))
+		fmt.Fprintf(writer, "* **Remaining:** %s\n", xtime.FormatDuration(report.Remaining(), config.Display.TimeFormat))
+		fmt.Fprintf(writer, "* **Overtime:** %s\n", xtime.FormatDuration(report.Overtime(), config.Display.TimeFormat))
+	}
+
+	fmt.Fprintln(writer)
+}
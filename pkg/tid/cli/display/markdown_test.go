@@ -0,0 +1,81 @@
+package display_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/tid/cli/display"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+// newTestReport creates a Report for 2017-03-01, with two entries, one of which has a note that
+// needs escaping in most output formats.
+func newTestReport(config types.Config) types.Report {
+	date := time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2017, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	sheet := types.NewTimesheet(date)
+	sheet.Entries = []types.Entry{
+		{Timesheet: sheet.Key, Hash: "abc1234000", Created: created, Updated: created, Note: "Plain", Duration: time.Hour},
+		{Timesheet: sheet.Key, Hash: "def5678000", Created: created, Updated: created, Note: "A | B\n<b>&</b>", Duration: 30 * time.Minute},
+	}
+
+	return types.NewReport(date, date, "default", []types.Timesheet{sheet}, config, types.NewCalendar())
+}
+
+func TestWriteEntriesMarkdown(t *testing.T) {
+	config := types.NewConfig()
+	config.Display.TimeFormat = xtime.FormatClock
+
+	var out bytes.Buffer
+
+	display.WriteEntriesMarkdown(newTestReport(config), &out, config, true)
+
+	expected := []string{
+		"# Report for 2017-03-01",
+		"* **Workspace:** default",
+		"* **Total Duration:** 1:30",
+		"* **Entry Count:** 2",
+		"## 2017-03-01",
+		"| Hash | Created | Updated | Note | Duration | Running |",
+		"| abc1234 | 9:00:00AM | 9:00:00AM | Plain | 1:00 | false |",
+		"| def5678 | 9:00:00AM | 9:00:00AM | A \\| B <b>&</b> | 0:30 | false |",
+		"| | | | **Subtotal** | **1:30** | |",
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("expected output to contain line %q, got:\n%s", line, out.String())
+		}
+	}
+
+	out.Reset()
+
+	display.WriteEntriesMarkdown(newTestReport(config), &out, config, false)
+
+	if strings.Contains(out.String(), "# Report for") {
+		t.Errorf("expected no summary, got:\n%s", out.String())
+	}
+}
+
+func TestWriteTimesheetsMarkdown(t *testing.T) {
+	config := types.NewConfig()
+	config.Display.TimeFormat = xtime.FormatClock
+
+	var out bytes.Buffer
+
+	display.WriteTimesheetsMarkdown(newTestReport(config), &out, config, false)
+
+	expected := "" +
+		"| Date | Entries | Duration |\n" +
+		"|------|---------|----------|\n" +
+		"| 2017-03-01 | 2 | 1:30 |\n" +
+		"| **TOTAL** | **2** | **1:30** |\n"
+
+	if out.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
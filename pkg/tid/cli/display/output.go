@@ -0,0 +1,46 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OutputFormat is an "enum" of the different formats listings and reports can be written in.
+type OutputFormat int
+
+// All possible output formats.
+const (
+	OutputTable OutputFormat = iota
+	OutputMarkdown
+	OutputHTML
+)
+
+var outputFormats = map[string]OutputFormat{
+	"table":    OutputTable,
+	"markdown": OutputMarkdown,
+	"html":     OutputHTML,
+}
+
+// Set parses the given string as an OutputFormat and sets it on this OutputFormat. This allows an
+// OutputFormat to be used as a console parameter value.
+func (f *OutputFormat) Set(text string) error {
+	format, ok := outputFormats[strings.ToLower(text)]
+	if !ok {
+		return fmt.Errorf("display: Invalid OutputFormat '%s'", text)
+	}
+
+	*f = format
+
+	return nil
+}
+
+// String returns the name of this OutputFormat.
+func (f OutputFormat) String() string {
+	for name, format := range outputFormats {
+		if format == f {
+			return name
+		}
+	}
+
+	return ""
+}
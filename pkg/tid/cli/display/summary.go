@@ -0,0 +1,43 @@
+package display
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+// WriteSummary writes a plain text summary of the given report to a writer. If there are targets
+// for the report's date range, then progress towards them is shown too.
+func WriteSummary(report types.Report, writer io.Writer, config types.Config) {
+	fmt.Fprintf(writer, "Report for %s.\n\n", report.DateRange())
+	fmt.Fprintf(writer, "Total Duration: %s\n", xtime.FormatDuration(report.Duration, config.Display.TimeFormat))
+	fmt.Fprintf(writer, "Entry Count: %d\n", report.EntryCount)
+
+	if report.Target > 0 {
+		fmt.Fprintf(writer, "Target: %s\n", xtime.FormatDuration(report.Target, config.Display.TimeFormat))
+		fmt.Fprintf(writer, "Remaining: %s\n", xtime.FormatDuration(report.Remaining(), config.Display.TimeFormat))
+		fmt.Fprintf(writer, "Overtime: %s\n", xtime.FormatDuration(report.Overtime(), config.Display.TimeFormat))
+	}
+
+	fmt.Fprintln(writer)
+}
+
+// WriteEntryLine writes a single line summary of the given entry to a writer, e.g. for showing the
+// status of a timer in a status bar.
+func WriteEntryLine(entry types.Entry, writer io.Writer, config types.Config) {
+	duration := xtime.FormatDuration(config.Display.RoundEntryDuration(entry.Duration), config.Display.TimeFormat)
+
+	fmt.Fprintf(writer, "%s (%s) %s", entry.Note, entry.ShortHash(), duration)
+
+	if entry.IsTimeboxed() {
+		fmt.Fprintf(writer, ", %s remaining", xtime.FormatDuration(entry.Remaining(), config.Display.TimeFormat))
+	}
+
+	if !entry.IsRunning {
+		fmt.Fprint(writer, " [stopped]")
+	}
+
+	fmt.Fprintln(writer)
+}
@@ -3,6 +3,7 @@ package display
 import (
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/SeerUK/tid/pkg/types"
@@ -30,7 +31,7 @@ func WriteEntriesTable(entries []types.Entry, writer io.Writer, config types.Con
 			entry.Created.Format(entry.CreatedTimeFormat()),
 			entry.Updated.Format(entry.UpdatedTimeFormat()),
 			entry.Note,
-			xtime.FormatDuration(entry.Duration, config.Display.TimeFormat),
+			xtime.FormatDuration(config.Display.RoundEntryDuration(entry.Duration), config.Display.TimeFormat),
 			fmt.Sprintf("%t", entry.IsRunning),
 		})
 	}
@@ -38,45 +39,275 @@ func WriteEntriesTable(entries []types.Entry, writer io.Writer, config types.Con
 	table.Render()
 }
 
-// WriteTimesheetsTable writes the given timesheets to a writer as a table.
-func WriteTimesheetsTable(sheets []types.Timesheet, writer io.Writer, config types.Config) {
+// WriteTimesheetsTable writes the timesheets in the given report to a writer as a table. If there
+// are targets for the report's date range, then progress towards them is shown too.
+func WriteTimesheetsTable(report types.Report, writer io.Writer, config types.Config) {
+	hasTargets := report.Target > 0
+
+	header := []string{
+		"Date",
+		"Entries",
+		"Duration",
+	}
+
+	if hasTargets {
+		header = append(header, "Target", "Remaining", "Overtime")
+	}
+
+	table := createTable(writer)
+	table.SetHeader(header)
+
+	for _, sheet := range report.Timesheets {
+		row := []string{
+			sheet.Label(),
+			fmt.Sprintf("%d", sheet.EntryCount),
+			xtime.FormatDuration(sheet.Duration, config.Display.TimeFormat),
+		}
+
+		if hasTargets {
+			row = append(row,
+				xtime.FormatDuration(sheet.Target, config.Display.TimeFormat),
+				xtime.FormatDuration(sheet.Remaining(), config.Display.TimeFormat),
+				xtime.FormatDuration(sheet.Overtime(), config.Display.TimeFormat),
+			)
+		}
+
+		table.Append(row)
+	}
+
+	// Footer, without affecting value formats
+	footer := []string{
+		"TOTAL",
+		fmt.Sprintf("%d", report.EntryCount),
+		xtime.FormatDuration(report.Duration, config.Display.TimeFormat),
+	}
+
+	if hasTargets {
+		footer = append(footer,
+			xtime.FormatDuration(report.Target, config.Display.TimeFormat),
+			xtime.FormatDuration(report.Remaining(), config.Display.TimeFormat),
+			xtime.FormatDuration(report.Overtime(), config.Display.TimeFormat),
+		)
+	}
+
+	table.Append(footer)
+	table.Render()
+}
+
+// WriteCalendarTable writes the given non-working days to a writer as a table.
+func WriteCalendarTable(days []types.CalendarDay, writer io.Writer) {
 	table := createTable(writer)
+	table.SetAutoMergeCells(false)
 	table.SetHeader([]string{
 		"Date",
+		"Weekday",
+		"Reason",
+	})
+
+	for _, day := range days {
+		weekday := ""
+
+		date, err := time.Parse(types.TimesheetKeyDateFmt, day.Date)
+		if err == nil {
+			weekday = date.Weekday().String()
+		}
+
+		table.Append([]string{
+			day.Date,
+			weekday,
+			day.Reason,
+		})
+	}
+
+	table.Render()
+}
+
+// WriteHistoryTable writes the given journal operations to a writer as a table. The given number of
+// operations, from the start, are marked as undone.
+func WriteHistoryTable(operations []types.JournalOperation, undone int, writer io.Writer) {
+	table := createTable(writer)
+	table.SetAutoMergeCells(false)
+	table.SetHeader([]string{
+		"Time",
+		"Operation",
 		"Entries",
+		"Undone",
+	})
+
+	for i, operation := range operations {
+		var entries []string
+
+		for _, entry := range operation.Entries {
+			entries = append(entries, fmt.Sprintf("%s %s", entry.ShortHash(), entry.Note))
+		}
+
+		table.Append([]string{
+			operation.Created.Format("2006-01-02 3:04:05PM"),
+			operation.Description,
+			strings.Join(entries, "\n"),
+			fmt.Sprintf("%t", i < undone),
+		})
+	}
+
+	table.Render()
+}
+
+// WriteEntryLogTable writes the revisions in the given entry log to a writer as a table. Values
+// that a revision changed are shown as they were before and after it.
+func WriteEntryLogTable(log types.EntryLog, writer io.Writer, config types.Config) {
+	table := createTable(writer)
+	table.SetAutoMergeCells(false)
+	table.SetHeader([]string{
+		"Time",
+		"Author",
+		"Action",
 		"Duration",
+		"Note",
 	})
 
-	var totalDuration time.Duration
-	var totalEntries int
+	for _, revision := range log.Revisions {
+		duration := xtime.FormatDuration(revision.Duration, config.Display.TimeFormat)
+		if revision.IsDurationChange() {
+			duration = fmt.Sprintf("%s -> %s", xtime.FormatDuration(revision.PreviousDuration, config.Display.TimeFormat), duration)
+		}
+
+		note := revision.Note
+		if revision.IsNoteChange() && revision.PreviousNote != "" {
+			note = fmt.Sprintf("%s -> %s", revision.PreviousNote, note)
+		}
+
+		table.Append([]string{
+			revision.Created.Format("2006-01-02 3:04:05PM"),
+			revision.Author,
+			revision.Action,
+			duration,
+			note,
+		})
+	}
+
+	table.Render()
+}
+
+// WriteSearchTable writes the entries in the given search results to a writer as a table. If
+// workspaces is true, the workspace each entry belongs to is shown too.
+func WriteSearchTable(results []types.SearchResult, workspaces bool, writer io.Writer, config types.Config) {
+	header := []string{"Date"}
 
-	for _, sheet := range sheets {
-		var duration time.Duration
+	if workspaces {
+		header = append(header, "Workspace")
+	}
+
+	table := createTable(writer)
+	table.SetHeader(append(header, "Hash", "Note", "Duration"))
+
+	for _, result := range results {
+		row := []string{result.Entry.Timesheet}
 
-		for _, e := range sheet.Entries {
-			duration = duration + e.Duration
+		if workspaces {
+			row = append(row, result.Workspace)
 		}
 
-		totalDuration = totalDuration + duration
-		totalEntries = totalEntries + len(sheet.Entries)
+		table.Append(append(row,
+			result.Entry.ShortHash(),
+			result.Entry.Note,
+			xtime.FormatDuration(config.Display.RoundEntryDuration(result.Entry.Duration), config.Display.TimeFormat),
+		))
+	}
+
+	table.Render()
+}
+
+// WriteSearchGroupsTable writes the given search result groups to a writer as a table, with the
+// total time spent on each, and on all of them.
+func WriteSearchGroupsTable(groups []types.SearchGroup, writer io.Writer, config types.Config) {
+	table := createTable(writer)
+	table.SetAutoMergeCells(false)
+	table.SetHeader([]string{
+		"Match",
+		"Entries",
+		"First",
+		"Last",
+		"Duration",
+	})
+
+	var entries int
+	var total time.Duration
+
+	for _, group := range groups {
+		entries += group.EntryCount
+		total += group.Duration
 
 		table.Append([]string{
-			sheet.Key,
-			fmt.Sprintf("%d", len(sheet.Entries)),
-			xtime.FormatDuration(duration, config.Display.TimeFormat),
+			group.Name,
+			fmt.Sprintf("%d", group.EntryCount),
+			group.First,
+			group.Last,
+			xtime.FormatDuration(group.Duration, config.Display.TimeFormat),
+		})
+	}
+
+	table.Append([]string{
+		"TOTAL",
+		fmt.Sprintf("%d", entries),
+		"",
+		"",
+		xtime.FormatDuration(total, config.Display.TimeFormat),
+	})
+
+	table.Render()
+}
+
+// WriteBalanceTable writes the balance of time worked against targets for each of the given
+// reports to a writer as a table, with a running total.
+func WriteBalanceTable(reports []types.Report, writer io.Writer, config types.Config) {
+	table := createTable(writer)
+	table.SetHeader([]string{
+		"Period",
+		"Worked",
+		"Target",
+		"Balance",
+		"Cumulative",
+	})
+
+	var totalWorked time.Duration
+	var totalTarget time.Duration
+
+	for _, report := range reports {
+		totalWorked = totalWorked + report.Duration
+		totalTarget = totalTarget + report.Target
+
+		table.Append([]string{
+			report.DateRange(),
+			xtime.FormatDuration(report.Duration, config.Display.TimeFormat),
+			xtime.FormatDuration(report.Target, config.Display.TimeFormat),
+			formatBalance(report.Duration-report.Target, config),
+			formatBalance(totalWorked-totalTarget, config),
 		})
 	}
 
 	// Footer, without affecting value formats
 	table.Append([]string{
 		"TOTAL",
-		fmt.Sprintf("%d", totalEntries),
-		xtime.FormatDuration(totalDuration, config.Display.TimeFormat),
+		xtime.FormatDuration(totalWorked, config.Display.TimeFormat),
+		xtime.FormatDuration(totalTarget, config.Display.TimeFormat),
+		formatBalance(totalWorked-totalTarget, config),
+		"",
 	})
 
 	table.Render()
 }
 
+// formatBalance formats a balance duration, explicitly signing positive balances.
+func formatBalance(balance time.Duration, config types.Config) string {
+	formatted := xtime.FormatDuration(balance, config.Display.TimeFormat)
+
+	if balance > 0 {
+		return "+" + formatted
+	}
+
+	return formatted
+}
+
 // createTable creates the base table instance with some default options set.
 func createTable(writer io.Writer) *tablewriter.Table {
 	table := tablewriter.NewWriter(writer)
@@ -0,0 +1,79 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+// TemplateNamePrefix is the prefix used to reference a named template from the config file, rather
+// than passing a template string directly (e.g. `--format=@weekly`).
+const TemplateNamePrefix = "@"
+
+// TemplatePrecision is the precision that times and durations are truncated to before they're
+// passed to templates. They're stored more precisely than anyone wants to read them.
+const TemplatePrecision = time.Second
+
+// ParseTemplate parses the given user-provided output template, with the helper functions from
+// TemplateFuncs available. If the format references a named template then the template is looked
+// up in the config instead.
+func ParseTemplate(name string, format string, config types.Config) (*template.Template, error) {
+	if strings.HasPrefix(format, TemplateNamePrefix) {
+		templateName := strings.TrimPrefix(format, TemplateNamePrefix)
+
+		namedFormat, ok := config.Templates[templateName]
+		if !ok {
+			return nil, fmt.Errorf("display: No template named '%s' in config", templateName)
+		}
+
+		format = namedFormat
+	}
+
+	tmpl, err := template.New(name).Funcs(TemplateFuncs(config)).Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("display: Invalid template: %v", err)
+	}
+
+	return tmpl, nil
+}
+
+// TemplateFuncs returns the helper functions made available to user-provided output templates.
+func TemplateFuncs(config types.Config) template.FuncMap {
+	return template.FuncMap{
+		// date formats a time using a Go time layout, e.g. `{{date "2006-01-02" .Created}}`.
+		"date": func(layout string, datetime time.Time) string {
+			return datetime.Format(layout)
+		},
+		// duration formats a duration using the configured duration format.
+		"duration": func(duration time.Duration) string {
+			return xtime.FormatDuration(duration, config.Display.TimeFormat)
+		},
+		// json encodes a value as JSON, e.g. `{{json .}}`.
+		"json": func(value interface{}) (string, error) {
+			bytes, err := json.Marshal(value)
+
+			return string(bytes), err
+		},
+		// lower converts a string to lower case.
+		"lower": strings.ToLower,
+		// pad pads a string with spaces to the given width. Negative widths pad on the left.
+		"pad": func(width int, value string) string {
+			if width < 0 {
+				return fmt.Sprintf("%*s", -width, value)
+			}
+
+			return fmt.Sprintf("%-*s", width, value)
+		},
+		// sum totals the durations of the given entries, applying any configured rounding.
+		"sum": func(entries []types.Entry) time.Duration {
+			return config.Display.TotalDuration(entries)
+		},
+		// upper converts a string to upper case.
+		"upper": strings.ToUpper,
+	}
+}
@@ -0,0 +1,100 @@
+package display_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/tid/cli/display"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+func TestParseTemplate(t *testing.T) {
+	config := types.NewConfig()
+	config.Templates = map[string]string{
+		"short": "{{.Hash}}: {{.Note}}",
+	}
+
+	entry := types.Entry{Hash: "abc1234", Note: "Working"}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"{{.Note}}", "Working"},
+		{"@short", "abc1234: Working"},
+	}
+
+	for _, test := range tests {
+		tmpl, err := display.ParseTemplate("entry", test.format, config)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", test.format, err)
+		}
+
+		var out bytes.Buffer
+
+		if err := tmpl.Execute(&out, entry); err != nil {
+			t.Fatalf("unexpected error executing %q: %v", test.format, err)
+		}
+
+		if out.String() != test.expected {
+			t.Errorf("expected %q to render %q, got %q", test.format, test.expected, out.String())
+		}
+	}
+
+	if _, err := display.ParseTemplate("entry", "@missing", config); err == nil {
+		t.Error("expected an error referencing an unknown named template")
+	}
+
+	if _, err := display.ParseTemplate("entry", "{{.Note", config); err == nil {
+		t.Error("expected an error parsing an invalid template")
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	config := types.NewConfig()
+	config.Display.TimeFormat = xtime.FormatClock
+	config.Display.Rounding = xtime.Duration(15 * time.Minute)
+	config.Display.RoundingMode = xtime.RoundUp
+
+	data := map[string]interface{}{
+		"Created":  time.Date(2017, 3, 1, 9, 30, 0, 0, time.UTC),
+		"Duration": 90 * time.Minute,
+		"Entries":  []types.Entry{{Duration: 10 * time.Minute}, {Duration: 20 * time.Minute}},
+		"Note":     "Working",
+		"Tags":     []string{"a", "b"},
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{`{{date "2006-01-02 15:04" .Created}}`, "2017-03-01 09:30"},
+		{`{{duration .Duration}}`, "1:30"},
+		{`{{json .Tags}}`, `["a","b"]`},
+		{`{{lower .Note}}`, "working"},
+		{`{{upper .Note}}`, "WORKING"},
+		{`[{{pad 10 .Note}}]`, "[Working   ]"},
+		{`[{{pad -10 .Note}}]`, "[   Working]"},
+		{`[{{pad 3 .Note}}]`, "[Working]"},
+		{`{{duration (sum .Entries)}}`, "0:45"},
+	}
+
+	for _, test := range tests {
+		tmpl, err := display.ParseTemplate("test", test.format, config)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", test.format, err)
+		}
+
+		var out bytes.Buffer
+
+		if err := tmpl.Execute(&out, data); err != nil {
+			t.Fatalf("unexpected error executing %q: %v", test.format, err)
+		}
+
+		if out.String() != test.expected {
+			t.Errorf("expected %q to render %q, got %q", test.format, test.expected, out.String())
+		}
+	}
+}
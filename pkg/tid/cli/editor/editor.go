@@ -0,0 +1,82 @@
+package editor
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/SeerUK/tid/pkg/toml"
+	"github.com/SeerUK/tid/pkg/types"
+)
+
+// DefaultEditor is the editor used if neither the VISUAL nor EDITOR environment variables are set.
+const DefaultEditor = "vi"
+
+// Edit writes the given data to a temporary file with the given extension, and opens it in the
+// user's editor. Once the editor exits, the contents of the file are returned.
+func Edit(data []byte, extension string) ([]byte, error) {
+	file, err := ioutil.TempFile("", "tid-*"+extension)
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	command := strings.Fields(Command())
+	if len(command) == 0 {
+		return nil, errors.New("editor: No editor is set")
+	}
+
+	cmd := exec.Command(command[0], append(command[1:], file.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, errors.New("editor: The editor exited with an error, nothing was changed")
+	}
+
+	return ioutil.ReadFile(file.Name())
+}
+
+// Command returns the command used to start the user's editor, from the VISUAL or EDITOR
+// environment variables.
+func Command() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if command := os.Getenv(name); strings.TrimSpace(command) != "" {
+			return command
+		}
+	}
+
+	return DefaultEditor
+}
+
+// EditEntries opens the given entries in the user's editor as TOML, and returns them as they are
+// once the editor exits, in the same order, ready to be applied with EntryFacade.Edit. Nothing is
+// returned if the file is left unchanged or emptied.
+func EditEntries(entries []types.Entry) ([]types.Entry, error) {
+	original := toml.EncodeEntries(entries)
+
+	data, err := Edit(original, ".toml")
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(data, original) || len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+
+	return toml.DecodeEntries(data, entries)
+}
@@ -11,17 +11,24 @@ import (
 type TidKernel struct {
 	// Backend provides an abstracted, but reasonably low-level interface to the underlying storage.
 	Backend state.Backend
+	// Connector opens additional connections to the underlying storage.
+	Connector state.Connector
 	// Config has all the configurations specified in the config file
 	Config types.Config
 	// Factory abstracts the creation of services.
 	Factory util.Factory
+	// Opener opens a writable connection to the underlying storage, for commands that run against a
+	// snapshot while they wait on the user.
+	Opener util.FactoryOpener
 }
 
 // NewTidKernel creates a new TidKernel, with services attached.
-func NewTidKernel(backend state.Backend, factory util.Factory, config types.Config) *TidKernel {
+func NewTidKernel(backend state.Backend, connector state.Connector, factory util.Factory, opener util.FactoryOpener, config types.Config) *TidKernel {
 	return &TidKernel{
-		Backend: backend,
-		Config:  config,
-		Factory: factory,
+		Backend:   backend,
+		Connector: connector,
+		Config:    config,
+		Factory:   factory,
+		Opener:    opener,
 	}
 }
@@ -0,0 +1,105 @@
+package cli
+
+// readOnlyCommands maps the names and aliases of commands that only query data, to the names and
+// aliases of their sub-commands that only query data. A nil value means the command itself only
+// queries data.
+var readOnlyCommands = map[string][]string{
+	"balance":   nil,
+	"bal":       nil,
+	"history":   nil,
+	"hist":      nil,
+	"report":    nil,
+	"rep":       nil,
+	"search":    nil,
+	"status":    nil,
+	"st":        nil,
+	"calendar":  {"list", "ls"},
+	"cal":       {"list", "ls"},
+	"entry":     {"list", "ls", "log"},
+	"e":         {"list", "ls", "log"},
+	"timesheet": {"list", "ls"},
+	"t":         {"list", "ls"},
+	"workspace": {"list", "ls"},
+	"w":         {"list", "ls"},
+}
+
+// interactiveCommands maps the names and aliases of commands that wait on the user (e.g. in their
+// editor, or to confirm a change) before changing anything, to the names and aliases of their
+// sub-commands that do so.
+var interactiveCommands = map[string][]string{
+	"entry":     {"delete", "d", "edit", "update", "u"},
+	"e":         {"delete", "d", "edit", "update", "u"},
+	"timesheet": {"edit"},
+	"t":         {"edit"},
+}
+
+// longRunningCommands maps the names and aliases of commands that only query data, but may take
+// long enough to read it that other tid processes shouldn't wait for them, to the names and aliases
+// of their sub-commands that do so.
+var longRunningCommands = map[string][]string{
+	"report": nil,
+	"rep":    nil,
+}
+
+// journalCommands maps the names and aliases of commands that work with the undo journal, to the
+// names and aliases of their sub-commands that do so.
+var journalCommands = map[string][]string{
+	"history": nil,
+	"hist":    nil,
+	"redo":    nil,
+	"undo":    nil,
+}
+
+// IsReadOnly returns true if the given arguments are for a command that only queries data, and so
+// can be run against a read-only database.
+func IsReadOnly(args []string) bool {
+	return isCommand(readOnlyCommands, args)
+}
+
+// IsInteractive returns true if the given arguments are for a command that waits on the user before
+// changing anything, and so should only open a writable database once it's done waiting.
+func IsInteractive(args []string) bool {
+	return isCommand(interactiveCommands, args)
+}
+
+// IsLongRunning returns true if the given arguments are for a command that may take long enough
+// that it should work from a copy of the database, rather than keep other tid processes waiting.
+func IsLongRunning(args []string) bool {
+	return isCommand(longRunningCommands, args)
+}
+
+// IsJournal returns true if the given arguments are for a command that works with the undo journal,
+// and so shouldn't have anything recorded in the journal before it runs.
+func IsJournal(args []string) bool {
+	return isCommand(journalCommands, args)
+}
+
+// isCommand returns true if the given arguments are for one of the given commands, which map the
+// names and aliases of commands to the names and aliases of their sub-commands. A nil value means
+// the command itself matches.
+func isCommand(commands map[string][]string, args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	subCommands, ok := commands[args[0]]
+	if !ok {
+		return false
+	}
+
+	if subCommands == nil {
+		return true
+	}
+
+	if len(args) < 2 {
+		return false
+	}
+
+	for _, subCommand := range subCommands {
+		if args[1] == subCommand {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,43 @@
+package tid
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+// EnvNow is the name of the environment variable that can be used to pretend tid is being run at a
+// different time, e.g. to reproduce bugs around midnight, or daylight saving time changes. It's
+// intentionally undocumented outside of the source.
+const EnvNow = "TID_NOW"
+
+// nowFormats are the formats accepted for the value of TID_NOW. Times without a time zone are in
+// the time zone being tracked in.
+var nowFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// GetClock returns the Clock that tid should get the current time from, in the given time zone. This
+// is the system's time, unless TID_NOW is set, in which case time starts from the given time instead.
+func GetClock(location *time.Location) (xtime.Clock, error) {
+	value := os.Getenv(EnvNow)
+	if value == "" {
+		return xtime.NewLocationClock(xtime.NewSystemClock(), location), nil
+	}
+
+	for _, format := range nowFormats {
+		now, err := time.ParseInLocation(format, value, location)
+		if err == nil {
+			return xtime.NewLocationClock(xtime.NewOffsetClock(now), location), nil
+		}
+	}
+
+	return nil, fmt.Errorf("tid: Invalid %s value '%s'", EnvNow, value)
+}
@@ -0,0 +1,49 @@
+package tid
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGetClock(t *testing.T) {
+	defer os.Unsetenv(EnvNow)
+
+	tests := []struct {
+		value    string
+		location *time.Location
+		expected time.Time
+	}{
+		{"2017-03-01T23:59:30Z", time.Local, time.Date(2017, 3, 1, 23, 59, 30, 0, time.UTC)},
+		{"2017-03-01T23:59:30+01:00", time.Local, time.Date(2017, 3, 1, 22, 59, 30, 0, time.UTC)},
+		{"2017-03-01 23:59:30", time.Local, time.Date(2017, 3, 1, 23, 59, 30, 0, time.Local)},
+		{"2017-03-01T23:59", time.Local, time.Date(2017, 3, 1, 23, 59, 0, 0, time.Local)},
+		{"2017-03-01", time.Local, time.Date(2017, 3, 1, 0, 0, 0, 0, time.Local)},
+		{"2017-03-01 23:59:30", time.UTC, time.Date(2017, 3, 1, 23, 59, 30, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		os.Setenv(EnvNow, test.value)
+
+		clock, err := GetClock(test.location)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.value, err)
+			continue
+		}
+
+		// The clock keeps running, but it can't have run for long.
+		if diff := clock.Now().Sub(test.expected); diff < 0 || diff > time.Minute {
+			t.Errorf("%s: expected clock to start at %s, got %s", test.value, test.expected, clock.Now())
+		}
+
+		if location := clock.Now().Location(); location != test.location {
+			t.Errorf("%s: expected clock to be in %s, got %s", test.value, test.location, location)
+		}
+	}
+
+	os.Setenv(EnvNow, "yesterday")
+
+	if _, err := GetClock(time.Local); err == nil {
+		t.Error("expected an error for an invalid value")
+	}
+}
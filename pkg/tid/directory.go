@@ -2,15 +2,91 @@ package tid
 
 import (
 	"fmt"
+	"os"
 	"os/user"
+	"path/filepath"
+	"strings"
 )
 
-// GetLocalDirectory returns the location to store all local tid files.
+const (
+	// EnvHome is the name of the environment variable that can be used to set the directory that
+	// all tid files are stored in.
+	EnvHome = "TID_HOME"
+	// EnvXDGConfigHome is the name of the XDG Base Directory environment variable for config files.
+	EnvXDGConfigHome = "XDG_CONFIG_HOME"
+	// EnvXDGDataHome is the name of the XDG Base Directory environment variable for data files.
+	EnvXDGDataHome = "XDG_DATA_HOME"
+)
+
+// currentUser returns the user running tid, whose home directory holds the default local directory.
+var currentUser = user.Current
+
+// Directories holds the locations that tid files are stored in.
+type Directories struct {
+	// Config is the directory that the config file is stored in.
+	Config string
+	// Data is the directory that the database is stored in, by default.
+	Data string
+}
+
+// GetLocalDirectory returns the default location to store all local tid files.
 func GetLocalDirectory() (string, error) {
-	usr, err := user.Current()
+	usr, err := currentUser()
 	if err != nil {
 		return "", err
 	}
 
 	return fmt.Sprintf("%s/.tid", usr.HomeDir), nil
 }
+
+// GetDirectories returns the locations to store tid files in. If a data directory is given, all
+// files are stored in it. Otherwise, the TID_HOME environment variable is used if it's set. If not,
+// and the default local directory doesn't already exist, the XDG Base Directory environment
+// variables are used if they're set. Otherwise, the default local directory is used.
+func GetDirectories(dataDir string) (Directories, error) {
+	if dataDir == "" {
+		dataDir = os.Getenv(EnvHome)
+	}
+
+	if dataDir != "" {
+		dir, err := ExpandPath(dataDir)
+
+		return Directories{Config: dir, Data: dir}, err
+	}
+
+	local, err := GetLocalDirectory()
+	if err != nil {
+		return Directories{}, err
+	}
+
+	dirs := Directories{Config: local, Data: local}
+
+	// Existing users keep using the directory they've always used.
+	if _, err := os.Stat(local); err == nil {
+		return dirs, nil
+	}
+
+	if xdgConfig := os.Getenv(EnvXDGConfigHome); xdgConfig != "" {
+		dirs.Config = filepath.Join(xdgConfig, "tid")
+	}
+
+	if xdgData := os.Getenv(EnvXDGDataHome); xdgData != "" {
+		dirs.Data = filepath.Join(xdgData, "tid")
+	}
+
+	return dirs, nil
+}
+
+// ExpandPath expands a leading "~" in the given path to the current user's home directory.
+func ExpandPath(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	usr, err := currentUser()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(usr.HomeDir, path[1:]), nil
+}
@@ -0,0 +1,118 @@
+package tid
+
+import (
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetDirectories(t *testing.T) {
+	home, err := ioutil.TempDir("", "tid-home")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(home)
+
+	defer func(original func() (*user.User, error)) { currentUser = original }(currentUser)
+
+	currentUser = func() (*user.User, error) {
+		return &user.User{HomeDir: home}, nil
+	}
+
+	for _, name := range []string{EnvHome, EnvXDGConfigHome, EnvXDGDataHome} {
+		defer func(name, value string, set bool) {
+			if set {
+				os.Setenv(name, value)
+			} else {
+				os.Unsetenv(name)
+			}
+		}(name, os.Getenv(name), os.Getenv(name) != "")
+	}
+
+	local := filepath.Join(home, ".tid")
+	under := filepath.Join(home, "data")
+	all := map[string]string{EnvHome: "/home", EnvXDGConfigHome: "/config", EnvXDGDataHome: "/xdg"}
+
+	tests := []struct {
+		name     string
+		dataDir  string
+		env      map[string]string
+		localDir bool
+		expected Directories
+	}{
+		{
+			name:     "default",
+			expected: Directories{Config: local, Data: local},
+		},
+		{
+			name:     "data directory",
+			dataDir:  "/data",
+			env:      all,
+			localDir: true,
+			expected: Directories{Config: "/data", Data: "/data"},
+		},
+		{
+			name:     "data directory under home",
+			dataDir:  "~/data",
+			expected: Directories{Config: under, Data: under},
+		},
+		{
+			name:     "tid home",
+			env:      all,
+			localDir: true,
+			expected: Directories{Config: "/home", Data: "/home"},
+		},
+		{
+			name:     "xdg",
+			env:      map[string]string{EnvXDGConfigHome: "/config", EnvXDGDataHome: "/xdg"},
+			expected: Directories{Config: "/config/tid", Data: "/xdg/tid"},
+		},
+		{
+			name:     "xdg config only",
+			env:      map[string]string{EnvXDGConfigHome: "/config"},
+			expected: Directories{Config: "/config/tid", Data: local},
+		},
+		{
+			name:     "xdg data only",
+			env:      map[string]string{EnvXDGDataHome: "/xdg"},
+			expected: Directories{Config: local, Data: "/xdg/tid"},
+		},
+		{
+			name:     "xdg with existing local directory",
+			env:      map[string]string{EnvXDGConfigHome: "/config", EnvXDGDataHome: "/xdg"},
+			localDir: true,
+			expected: Directories{Config: local, Data: local},
+		},
+	}
+
+	for _, test := range tests {
+		for _, name := range []string{EnvHome, EnvXDGConfigHome, EnvXDGDataHome} {
+			os.Unsetenv(name)
+		}
+
+		for name, value := range test.env {
+			os.Setenv(name, value)
+		}
+
+		os.RemoveAll(local)
+
+		if test.localDir {
+			if err := os.Mkdir(local, 0755); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		dirs, err := GetDirectories(test.dataDir)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		if dirs != test.expected {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, dirs)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package toml
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/naoina/toml"
+)
+
+// EntryCreatedFmt is the format of the created time of entries when they're being edited.
+const EntryCreatedFmt = "2006-01-02 15:04:05"
+
+// entriesHeader is written at the start of entries being edited, to explain how to edit them.
+const entriesHeader = `# Edit the entries below, then save and close this file to apply the changes.
+#
+# The date is the date of the timesheet an entry belongs to, the created time uses the format
+# "YYYY-MM-DD HH:MM:SS", and the duration uses Go's duration format (e.g. "1h30m").
+# Entries can't be added or removed here, and their hashes can't be changed. Leave the file
+# unchanged, or empty it, to cancel.
+`
+
+// editedEntries is the TOML representation of a set of entries that have been edited.
+type editedEntries struct {
+	Entries []editedEntry `toml:"entry"`
+}
+
+// editedEntry is the TOML representation of an entry that has been edited. Values are nil if their
+// key was removed, in which case they're left unchanged.
+type editedEntry struct {
+	Hash     string  `toml:"hash"`
+	Date     *string `toml:"date"`
+	Created  *string `toml:"created"`
+	Duration *string `toml:"duration"`
+	Note     *string `toml:"note"`
+}
+
+// editableEntry is the TOML representation of an entry that is being edited.
+type editableEntry struct {
+	Hash     string `toml:"hash"`
+	Date     string `toml:"date"`
+	Created  string `toml:"created"`
+	Duration string `toml:"duration"`
+	Note     string `toml:"note"`
+}
+
+// EncodeEntries renders the given entries as a TOML document that can be edited, then read back
+// with DecodeEntries.
+func EncodeEntries(entries []types.Entry) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(entriesHeader)
+
+	for _, entry := range entries {
+		editable := newEditableEntry(entry)
+
+		buf.WriteString("\n[[entry]]\n")
+		buf.WriteString(fmt.Sprintf("hash = %s\n", quote(editable.Hash)))
+		buf.WriteString(fmt.Sprintf("date = %s\n", quote(editable.Date)))
+		buf.WriteString(fmt.Sprintf("created = %s\n", quote(editable.Created)))
+		buf.WriteString(fmt.Sprintf("duration = %s\n", quote(editable.Duration)))
+		buf.WriteString(fmt.Sprintf("note = %s\n", quote(editable.Note)))
+	}
+
+	return buf.Bytes()
+}
+
+// DecodeEntries reads the given TOML document, as rendered by EncodeEntries for the given entries
+// and then edited, and returns a copy of each of the given entries with the edits applied, in the
+// same order. Entries missing from the document, and values whose key was removed, are left
+// unchanged. Values that weren't edited are kept exactly as they were, even if the document shows
+// them less precisely.
+func DecodeEntries(data []byte, entries []types.Entry) ([]types.Entry, error) {
+	var document editedEntries
+
+	if err := toml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("toml: Invalid entries: %v", err)
+	}
+
+	edited := append([]types.Entry{}, entries...)
+	seen := make(map[string]bool)
+
+	for _, editable := range document.Entries {
+		index := -1
+
+		for i, entry := range entries {
+			if editable.Hash != "" && (entry.ShortHash() == editable.Hash || entry.Hash == editable.Hash) {
+				index = i
+				break
+			}
+		}
+
+		if index < 0 {
+			return nil, fmt.Errorf("toml: Unknown entry '%s', entries can't be added or have their hash changed", editable.Hash)
+		}
+
+		if seen[editable.Hash] {
+			return nil, fmt.Errorf("toml: Entry '%s' appears more than once", editable.Hash)
+		}
+
+		seen[editable.Hash] = true
+
+		entry, err := editable.apply(entries[index])
+		if err != nil {
+			return nil, err
+		}
+
+		edited[index] = entry
+	}
+
+	return edited, nil
+}
+
+// newEditableEntry creates a new editableEntry from the given entry. Durations are shown to the
+// second, like everywhere else, and are only changed if they're edited.
+func newEditableEntry(entry types.Entry) editableEntry {
+	return editableEntry{
+		Hash:     entry.ShortHash(),
+		Date:     entry.Timesheet,
+		Created:  entry.Created.Format(EntryCreatedFmt),
+		Duration: entry.Duration.Truncate(time.Second).String(),
+		Note:     entry.Note,
+	}
+}
+
+// apply returns a copy of the given entry, with any of the values in this editedEntry that differ
+// from how the entry was rendered applied to it.
+func (e editedEntry) apply(entry types.Entry) (types.Entry, error) {
+	original := newEditableEntry(entry)
+
+	if e.Date != nil && *e.Date != original.Date {
+		if _, err := time.Parse(types.TimesheetKeyDateFmt, *e.Date); err != nil {
+			return entry, fmt.Errorf("toml: Invalid date '%s' for entry '%s'", *e.Date, e.Hash)
+		}
+
+		entry.Timesheet = *e.Date
+	}
+
+	if e.Created != nil && *e.Created != original.Created {
+		created, err := time.ParseInLocation(EntryCreatedFmt, *e.Created, entry.Created.Location())
+		if err != nil {
+			return entry, fmt.Errorf("toml: Invalid created time '%s' for entry '%s'", *e.Created, e.Hash)
+		}
+
+		entry.Created = created
+	}
+
+	if e.Duration != nil && *e.Duration != original.Duration {
+		duration, err := time.ParseDuration(*e.Duration)
+		if err != nil || duration < 0 {
+			return entry, fmt.Errorf("toml: Invalid duration '%s' for entry '%s'", *e.Duration, e.Hash)
+		}
+
+		entry.Duration = duration
+	}
+
+	if e.Note != nil {
+		entry.Note = *e.Note
+	}
+
+	return entry, nil
+}
+
+// quote returns the given string as a TOML basic string.
+func quote(s string) string {
+	var buf strings.Builder
+
+	buf.WriteByte('"')
+
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\r':
+			buf.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				buf.WriteString(fmt.Sprintf(`\u%04X`, r))
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+
+	buf.WriteByte('"')
+
+	return buf.String()
+}
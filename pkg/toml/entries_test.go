@@ -0,0 +1,89 @@
+package toml_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/toml"
+	"github.com/SeerUK/tid/pkg/types"
+)
+
+func TestDecodeEntries(t *testing.T) {
+	entry := types.NewEntry(time.Date(2017, 3, 1, 9, 0, 0, 500, time.UTC))
+	entry.Timesheet = "2017-03-01"
+	entry.Duration = time.Hour + time.Nanosecond
+	entry.Note = "Quotes \" and \\ and\ttabs"
+
+	other := types.NewEntry(time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC))
+	other.Timesheet = "2017-03-01"
+	other.Note = "Other"
+
+	entries := []types.Entry{entry, other}
+	data := toml.EncodeEntries(entries)
+
+	unchanged, err := toml.DecodeEntries(data, entries)
+	if err != nil {
+		t.Fatalf("unexpected error decoding unchanged entries: %v", err)
+	}
+
+	for i := range entries {
+		if unchanged[i] != entries[i] {
+			t.Errorf("expected entry %d to be unchanged, got: %+v", i, unchanged[i])
+		}
+	}
+
+	edited := strings.Replace(string(data), `duration = "1h0m0s"`, `duration = "2h"`, 1)
+	edited = strings.Replace(edited, `created = "2017-03-01 09:00:00"`, `created = "2017-03-01 08:30:00"`, 1)
+	edited = strings.Replace(edited, `date = "2017-03-01"`, `date = "2017-03-02"`, 1)
+
+	decoded, err := toml.DecodeEntries([]byte(edited), entries)
+	if err != nil {
+		t.Fatalf("unexpected error decoding edited entries: %v", err)
+	}
+
+	if decoded[0].Duration != 2*time.Hour || decoded[0].Timesheet != "2017-03-02" || decoded[0].Note != entry.Note {
+		t.Errorf("unexpected edited entry: %+v", decoded[0])
+	}
+
+	if !decoded[0].Created.Equal(time.Date(2017, 3, 1, 8, 30, 0, 0, time.UTC)) {
+		t.Errorf("expected created time to be edited, got %s", decoded[0].Created)
+	}
+
+	if decoded[1] != other {
+		t.Errorf("expected other entry to be unchanged, got: %+v", decoded[1])
+	}
+
+	removed := strings.Replace(string(data), `note = "Other"`+"\n", "", 1)
+	removed = strings.Replace(removed, `duration = "0s"`+"\n", "", 1)
+
+	decoded, err = toml.DecodeEntries([]byte(removed), entries)
+	if err != nil {
+		t.Fatalf("unexpected error decoding entries with removed values: %v", err)
+	}
+
+	if decoded[1] != other {
+		t.Errorf("expected removed values to be unchanged, got: %+v", decoded[1])
+	}
+
+	cleared := strings.Replace(string(data), `note = "Other"`, `note = ""`, 1)
+
+	decoded, err = toml.DecodeEntries([]byte(cleared), entries)
+	if err != nil || decoded[1].Note != "" {
+		t.Errorf("expected an empty note to clear the note, got %+v, %v", decoded[1], err)
+	}
+
+	invalid := map[string]string{
+		"duration": strings.Replace(string(data), `duration = "0s"`, `duration = "soon"`, 1),
+		"date":     strings.Replace(string(data), `date = "2017-03-01"`, `date = "tomorrow"`, 1),
+		"hash":     strings.Replace(string(data), other.ShortHash(), "abcdefg", 1),
+		"key":      strings.Replace(string(data), `note = "Other"`, `notes = "Other"`, 1),
+		"syntax":   strings.Replace(string(data), `note = "Other"`, `note = "Other`, 1),
+	}
+
+	for name, document := range invalid {
+		if _, err := toml.DecodeEntries([]byte(document), entries); err == nil {
+			t.Errorf("expected an error decoding entries with an invalid %s", name)
+		}
+	}
+}
@@ -31,6 +31,30 @@ func Open(tidDir string) (types.Config, error) {
 
 	defer f.Close()
 
+	return decode(f, config)
+}
+
+// Read reads the configuration file without creating it. If it doesn't exist, the default
+// configuration is returned.
+func Read(tidDir string) (types.Config, error) {
+	config := types.NewConfig()
+
+	f, err := os.Open(filepath.Join(tidDir, TomlConfigFilename))
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+
+	if err != nil {
+		return config, err
+	}
+
+	defer f.Close()
+
+	return decode(f, config)
+}
+
+// decode decodes the given configuration file over the given configuration.
+func decode(f *os.File, config types.Config) (types.Config, error) {
 	fileStat, err := f.Stat()
 	if err != nil {
 		return config, err
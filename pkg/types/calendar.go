@@ -0,0 +1,91 @@
+package types
+
+import (
+	"sort"
+	"time"
+
+	"github.com/SeerUK/tid/proto"
+)
+
+// Calendar represents a calendar of non-working days, e.g. holidays and leave.
+type Calendar struct {
+	// Days is an array of the non-working days, sorted by date.
+	Days []CalendarDay
+}
+
+// CalendarDay represents a single non-working day.
+type CalendarDay struct {
+	// The date of the day, in the same format as timesheet keys.
+	Date string
+	// The reason this day is a non-working day.
+	Reason string
+}
+
+// NewCalendar creates a new instance of Calendar.
+func NewCalendar() Calendar {
+	return Calendar{}
+}
+
+// FromMessage reads a `proto.SysCalendar` message into this Calendar.
+func (c *Calendar) FromMessage(message *proto.SysCalendar) {
+	for _, day := range message.Days {
+		c.Days = append(c.Days, CalendarDay{
+			Date:   day.Date,
+			Reason: day.Reason,
+		})
+	}
+}
+
+// ToMessage converts this Calendar into a `proto.SysCalendar`.
+func (c *Calendar) ToMessage() *proto.SysCalendar {
+	message := proto.SysCalendar{}
+
+	for _, day := range c.Days {
+		message.Days = append(message.Days, &proto.SysCalendarDay{
+			Date:   day.Date,
+			Reason: day.Reason,
+		})
+	}
+
+	return &message
+}
+
+// Add adds the given day to the calendar, replacing any existing day with the same date.
+func (c *Calendar) Add(day CalendarDay) {
+	c.Remove(day.Date)
+	c.Days = append(c.Days, day)
+
+	sort.Slice(c.Days, func(i, j int) bool {
+		return c.Days[i].Date < c.Days[j].Date
+	})
+}
+
+// Remove removes the day with the given date from the calendar. Returns true if a day was removed.
+func (c *Calendar) Remove(date string) bool {
+	for i, day := range c.Days {
+		if day.Date == date {
+			c.Days = append(c.Days[:i], c.Days[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Find attempts to find the day with the given date in the calendar.
+func (c Calendar) Find(date string) (CalendarDay, bool) {
+	for _, day := range c.Days {
+		if day.Date == date {
+			return day, true
+		}
+	}
+
+	return CalendarDay{}, false
+}
+
+// IsNonWorking returns true if the given date is a non-working day.
+func (c Calendar) IsNonWorking(date time.Time) bool {
+	_, ok := c.Find(date.Format(TimesheetKeyDateFmt))
+
+	return ok
+}
@@ -1,26 +1,254 @@
 package types
 
 import (
+	"time"
+
 	"github.com/SeerUK/tid/pkg/xtime"
 )
 
+// DefaultLockTimeout is how long to wait for another tid process to release the database, if no
+// lock timeout is configured.
+const DefaultLockTimeout = 5 * time.Second
+
 // Config represents the application configuration format.
 type Config struct {
-	Display ConfigDisplay
+	Database ConfigDatabase
+	Display  ConfigDisplay
+	Targets  ConfigTargets
+	Tracking ConfigTracking
+	// Templates are named output templates, usable with `--format=@name`.
+	Templates map[string]string
+}
+
+// ConfigDatabase represents configuration for accessing the database.
+type ConfigDatabase struct {
+	// Path is the path to an alternate database file. Relative paths are relative to the directory
+	// containing the config file.
+	Path string
+	// LockTimeout is how long to wait for another tid process to release the database.
+	LockTimeout xtime.Duration
 }
 
 // ConfigDisplay represents configuration for output.
 type ConfigDisplay struct {
-	TimeFormat   xtime.DurationFormat
-	FirstWeekday xtime.Weekday
+	TimeFormat    xtime.DurationFormat
+	FirstWeekday  xtime.Weekday
+	Rounding      xtime.Duration
+	RoundingMode  xtime.RoundingMode
+	RoundingScope xtime.RoundingScope
+	// Timezone is the time zone to show times in. If it's not set, times are shown in the time zone
+	// they were recorded in.
+	Timezone xtime.Location
+}
+
+// ConfigTargets represents configuration for the amount of time that is expected to be worked.
+type ConfigTargets struct {
+	Monday    xtime.Duration
+	Tuesday   xtime.Duration
+	Wednesday xtime.Duration
+	Thursday  xtime.Duration
+	Friday    xtime.Duration
+	Saturday  xtime.Duration
+	Sunday    xtime.Duration
+	// Weekly is the target for a whole week. If set, it's used instead of the daily targets for
+	// any whole week within a period.
+	Weekly xtime.Duration
+	// Workspaces are targets for specific workspaces, used instead of these targets.
+	Workspaces map[string]ConfigTargets
+}
+
+// ConfigTracking represents configuration for time tracking behaviour.
+type ConfigTracking struct {
+	// MaxRunning is how long a timer can run without being observed before it's considered idle. A
+	// value of 0 disables idle detection.
+	MaxRunning xtime.Duration
+	// IdleAction is what to do with the time on an idle timer when not prompting the user.
+	IdleAction IdleAction
+	// Midnight is how time tracked by a timer that runs past the end of the day is attributed.
+	Midnight MidnightPolicy
+	// Resume is how resuming an entry from a previous day is attributed to timesheets.
+	Resume ResumePolicy
+	// DayRollover is how long after midnight a new day begins. New timers started before then are
+	// tracked against the previous day's timesheet.
+	DayRollover xtime.Duration
+	// Timezone is the time zone to track time in, which decides which day's timesheet time is
+	// tracked against. If it's not set, the system's time zone is used.
+	Timezone xtime.Location
 }
 
 // NewConfig creates a Config struct with default values.
 func NewConfig() Config {
 	return Config{
 		Display: ConfigDisplay{
-			TimeFormat:   xtime.FormatText,
-			FirstWeekday: xtime.Monday,
+			TimeFormat:    xtime.FormatText,
+			FirstWeekday:  xtime.Monday,
+			RoundingMode:  xtime.RoundNearest,
+			RoundingScope: xtime.RoundEntry,
+		},
+		Tracking: ConfigTracking{
+			IdleAction: IdleCap,
 		},
 	}
 }
+
+// Timeout returns how long to wait for another tid process to release the database, falling back
+// to the default if no timeout is configured.
+func (d ConfigDatabase) Timeout() time.Duration {
+	if d.LockTimeout <= 0 {
+		return DefaultLockTimeout
+	}
+
+	return d.LockTimeout.TimeDuration()
+}
+
+// DayBoundary returns when one tracking day ends and the next begins. A rollover of a day or more
+// is ignored.
+func (t ConfigTracking) DayBoundary() DayBoundary {
+	rollover := t.DayRollover.TimeDuration()
+	if rollover < 0 || rollover >= 24*time.Hour {
+		rollover = 0
+	}
+
+	return DayBoundary{
+		Policy:   t.Midnight,
+		Rollover: rollover,
+	}
+}
+
+// ForWorkspace returns the targets to use for the workspace with the given name.
+func (t ConfigTargets) ForWorkspace(name string) ConfigTargets {
+	if targets, ok := t.Workspaces[name]; ok {
+		return targets
+	}
+
+	return t
+}
+
+// Daily returns the target for the given day of the week.
+func (t ConfigTargets) Daily(weekday time.Weekday) time.Duration {
+	var target xtime.Duration
+
+	switch weekday {
+	case time.Monday:
+		target = t.Monday
+	case time.Tuesday:
+		target = t.Tuesday
+	case time.Wednesday:
+		target = t.Wednesday
+	case time.Thursday:
+		target = t.Thursday
+	case time.Friday:
+		target = t.Friday
+	case time.Saturday:
+		target = t.Saturday
+	case time.Sunday:
+		target = t.Sunday
+	}
+
+	return target.TimeDuration()
+}
+
+// Expected returns the amount of time expected to be worked between the given start and end dates
+// (inclusive), i.e. the total of ExpectedOn for each date.
+func (t ConfigTargets) Expected(start time.Time, end time.Time, firstWeekday time.Weekday, calendar Calendar) time.Duration {
+	var expected time.Duration
+
+	for current := start; !current.After(end); current = current.AddDate(0, 0, 1) {
+		expected = expected + t.ExpectedOn(current, firstWeekday, calendar)
+	}
+
+	return expected
+}
+
+// ExpectedOn returns the amount of time expected to be worked on the given date. If a weekly target
+// is set, it's spread across the working days of the week the date is in (beginning on the given
+// first weekday), in proportion to their daily targets, or evenly from Monday to Friday if there
+// are none. Otherwise, the daily target is used. Non-working days in the given calendar aren't
+// expected to be worked, and don't move their share of a weekly target onto other days.
+func (t ConfigTargets) ExpectedOn(date time.Time, firstWeekday time.Weekday, calendar Calendar) time.Duration {
+	if calendar.IsNonWorking(date) {
+		return 0
+	}
+
+	if t.Weekly <= 0 {
+		return t.Daily(date.Weekday())
+	}
+
+	weights := t.weeklyWeights()
+
+	var total, before int64
+
+	for i := 0; i < 7; i++ {
+		weekday := time.Weekday((int(firstWeekday) + i) % 7)
+		if weekday == date.Weekday() {
+			before = total
+		}
+
+		total = total + weights[weekday]
+	}
+
+	// Each day's share is the difference between two running totals, so a whole week's shares
+	// always add up to exactly the weekly target.
+	weekly := int64(t.Weekly)
+	share := func(weight int64) int64 {
+		if weight == total {
+			return weekly
+		}
+
+		return int64(float64(weekly) * float64(weight) / float64(total))
+	}
+
+	return time.Duration(share(before+weights[date.Weekday()]) - share(before))
+}
+
+// weeklyWeights returns how much of the weekly target should be worked on each day of the week,
+// relative to the other days.
+func (t ConfigTargets) weeklyWeights() [7]int64 {
+	var weights [7]int64
+	var hasDaily bool
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		weights[weekday] = int64(t.Daily(weekday))
+		hasDaily = hasDaily || weights[weekday] > 0
+	}
+
+	if !hasDaily {
+		for weekday := time.Monday; weekday <= time.Friday; weekday++ {
+			weights[weekday] = 1
+		}
+	}
+
+	return weights
+}
+
+// RoundEntryDuration rounds the given entry duration for output, if rounding is configured to
+// apply to individual entries. Stored durations are never rounded.
+func (d ConfigDisplay) RoundEntryDuration(duration time.Duration) time.Duration {
+	if d.RoundingScope != xtime.RoundEntry {
+		return duration
+	}
+
+	return xtime.RoundDuration(duration, d.Rounding.TimeDuration(), d.RoundingMode)
+}
+
+// RoundTotalDuration rounds the given total duration for output, if rounding is configured to
+// apply to totals. Stored durations are never rounded.
+func (d ConfigDisplay) RoundTotalDuration(duration time.Duration) time.Duration {
+	if d.RoundingScope != xtime.RoundTotal {
+		return duration
+	}
+
+	return xtime.RoundDuration(duration, d.Rounding.TimeDuration(), d.RoundingMode)
+}
+
+// TotalDuration returns the total duration of the given entries for output, with rounding applied
+// to the entries or the total as configured.
+func (d ConfigDisplay) TotalDuration(entries []Entry) time.Duration {
+	var duration time.Duration
+
+	for _, e := range entries {
+		duration = duration + d.RoundEntryDuration(e.Duration)
+	}
+
+	return d.RoundTotalDuration(duration)
+}
@@ -0,0 +1,147 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+func TestConfigDisplayRounding(t *testing.T) {
+	tests := []struct {
+		scope xtime.RoundingScope
+		entry time.Duration
+		total time.Duration
+	}{
+		{xtime.RoundEntry, 15 * time.Minute, 10 * time.Minute},
+		{xtime.RoundTotal, 10 * time.Minute, 15 * time.Minute},
+	}
+
+	for _, test := range tests {
+		display := ConfigDisplay{
+			Rounding:      xtime.Duration(15 * time.Minute),
+			RoundingMode:  xtime.RoundUp,
+			RoundingScope: test.scope,
+		}
+
+		if entry := display.RoundEntryDuration(10 * time.Minute); entry != test.entry {
+			t.Errorf("expected an entry duration of %s with scope %d, got %s", test.entry, test.scope, entry)
+		}
+
+		if total := display.RoundTotalDuration(10 * time.Minute); total != test.total {
+			t.Errorf("expected a total duration of %s with scope %d, got %s", test.total, test.scope, total)
+		}
+	}
+
+	display := ConfigDisplay{
+		Rounding:      xtime.Duration(15 * time.Minute),
+		RoundingMode:  xtime.RoundUp,
+		RoundingScope: xtime.RoundEntry,
+	}
+
+	entries := []Entry{{Duration: 10 * time.Minute}, {Duration: 10 * time.Minute}}
+
+	if total := display.TotalDuration(entries); total != 30*time.Minute {
+		t.Errorf("expected each entry to be rounded before totalling, got %s", total)
+	}
+
+	display.RoundingScope = xtime.RoundTotal
+
+	if total := display.TotalDuration(entries); total != 30*time.Minute {
+		t.Errorf("expected the total to be rounded, got %s", total)
+	}
+}
+
+func TestConfigTargetsDaily(t *testing.T) {
+	targets := ConfigTargets{
+		Monday:   xtime.Duration(8 * time.Hour),
+		Friday:   xtime.Duration(6 * time.Hour),
+		Saturday: xtime.Duration(time.Hour),
+	}
+
+	tests := []struct {
+		weekday  time.Weekday
+		expected time.Duration
+	}{
+		{time.Monday, 8 * time.Hour},
+		{time.Tuesday, 0},
+		{time.Friday, 6 * time.Hour},
+		{time.Saturday, time.Hour},
+		{time.Sunday, 0},
+	}
+
+	for _, test := range tests {
+		if actual := targets.Daily(test.weekday); actual != test.expected {
+			t.Errorf("expected a target of %s on %s, got %s", test.expected, test.weekday, actual)
+		}
+	}
+}
+
+func TestConfigTargetsExpected(t *testing.T) {
+	daily := ConfigTargets{
+		Monday:    xtime.Duration(8 * time.Hour),
+		Tuesday:   xtime.Duration(8 * time.Hour),
+		Wednesday: xtime.Duration(8 * time.Hour),
+		Thursday:  xtime.Duration(8 * time.Hour),
+		Friday:    xtime.Duration(6 * time.Hour),
+	}
+
+	weighted := daily
+	weighted.Weekly = xtime.Duration(19 * time.Hour)
+
+	weekly := ConfigTargets{Weekly: xtime.Duration(40 * time.Hour)}
+
+	calendar := NewCalendar()
+	calendar.Add(CalendarDay{Date: "2017-03-08"})
+
+	date := func(day int) time.Time {
+		return time.Date(2017, 3, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	// 2017-03-06 is a Monday.
+	tests := []struct {
+		name     string
+		targets  ConfigTargets
+		start    time.Time
+		end      time.Time
+		first    time.Weekday
+		calendar Calendar
+		expected time.Duration
+	}{
+		{"daily whole week", daily, date(6), date(12), time.Monday, Calendar{}, 38 * time.Hour},
+		{"daily partial week", daily, date(9), date(10), time.Monday, Calendar{}, 14 * time.Hour},
+		{"daily with a day off", daily, date(6), date(12), time.Monday, calendar, 30 * time.Hour},
+		{"weekly whole week", weekly, date(6), date(12), time.Monday, Calendar{}, 40 * time.Hour},
+		{"weekly whole weeks", weekly, date(6), date(19), time.Monday, Calendar{}, 80 * time.Hour},
+		{"weekly first partial week", weekly, date(9), date(12), time.Monday, Calendar{}, 16 * time.Hour},
+		{"weekly last partial week", weekly, date(6), date(7), time.Monday, Calendar{}, 16 * time.Hour},
+		{"weekly spanning two weeks", weekly, date(9), date(14), time.Monday, Calendar{}, 32 * time.Hour},
+		{"weekly weekend only", weekly, date(11), date(12), time.Monday, Calendar{}, 0},
+		{"weekly with a day off", weekly, date(6), date(12), time.Monday, calendar, 32 * time.Hour},
+		{"weekly starting sunday", weekly, date(5), date(11), time.Sunday, Calendar{}, 40 * time.Hour},
+		{"weighted whole week", weighted, date(6), date(12), time.Monday, Calendar{}, 19 * time.Hour},
+		{"weighted partial week", weighted, date(6), date(6), time.Monday, Calendar{}, 4 * time.Hour},
+		{"weighted friday", weighted, date(10), date(10), time.Monday, Calendar{}, 3 * time.Hour},
+	}
+
+	for _, test := range tests {
+		actual := test.targets.Expected(test.start, test.end, test.first, test.calendar)
+		if actual != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestConfigTargetsExpectedOnSplitsWeeksExactly(t *testing.T) {
+	targets := ConfigTargets{Weekly: xtime.Duration(37*time.Hour + 30*time.Minute)}
+
+	var total time.Duration
+
+	for day := 6; day <= 12; day++ {
+		total = total + targets.ExpectedOn(time.Date(2017, 3, day, 0, 0, 0, 0, time.UTC), time.Monday, Calendar{})
+	}
+
+	if total != 37*time.Hour+30*time.Minute {
+		t.Errorf("expected a whole week's shares to add up to the weekly target, got %s", total)
+	}
+}
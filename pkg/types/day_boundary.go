@@ -0,0 +1,32 @@
+package types
+
+import "time"
+
+// DayBoundary describes when one tracking day ends and the next begins, and what happens to time
+// tracked across that point.
+type DayBoundary struct {
+	// Policy is how time tracked across the end of a day is attributed to timesheets.
+	Policy MidnightPolicy
+	// Rollover is how long after midnight a new day begins, e.g. 4 hours to treat anything before
+	// 04:00 as part of the previous day.
+	Rollover time.Duration
+}
+
+// Date returns the date of the tracking day that the given time falls within.
+func (b DayBoundary) Date(t time.Time) time.Time {
+	year, month, day := t.Add(-b.Rollover).Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// Key returns the key of the timesheet for the tracking day that the given time falls within.
+func (b DayBoundary) Key(t time.Time) string {
+	return b.Date(t).Format(TimesheetKeyDateFmt)
+}
+
+// Next returns the time that the tracking day after the one the given time falls within begins.
+func (b DayBoundary) Next(t time.Time) time.Time {
+	date := b.Date(t)
+
+	return time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, t.Location()).Add(b.Rollover)
+}
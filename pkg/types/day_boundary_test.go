@@ -0,0 +1,42 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+func TestDayBoundary(t *testing.T) {
+	tests := []struct {
+		rollover time.Duration
+		at       time.Time
+		key      string
+		next     time.Time
+	}{
+		{0, time.Date(2017, 3, 1, 23, 0, 0, 0, time.UTC), "2017-03-01", time.Date(2017, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{0, time.Date(2017, 3, 2, 0, 0, 0, 0, time.UTC), "2017-03-02", time.Date(2017, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{4 * time.Hour, time.Date(2017, 3, 2, 3, 59, 0, 0, time.UTC), "2017-03-01", time.Date(2017, 3, 2, 4, 0, 0, 0, time.UTC)},
+		{4 * time.Hour, time.Date(2017, 3, 2, 4, 0, 0, 0, time.UTC), "2017-03-02", time.Date(2017, 3, 3, 4, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		boundary := DayBoundary{Rollover: test.rollover}
+
+		if key := boundary.Key(test.at); key != test.key {
+			t.Errorf("expected %s to be on %s with a %s rollover, got %s", test.at, test.key, test.rollover, key)
+		}
+
+		if next := boundary.Next(test.at); !next.Equal(test.next) {
+			t.Errorf("expected the day after %s to begin at %s, got %s", test.at, test.next, next)
+		}
+	}
+}
+
+func TestConfigTrackingDayBoundary(t *testing.T) {
+	config := ConfigTracking{DayRollover: xtime.Duration(25 * time.Hour)}
+
+	if boundary := config.DayBoundary(); boundary.Rollover != 0 {
+		t.Errorf("expected a rollover of a day or more to be ignored, got %s", boundary.Rollover)
+	}
+}
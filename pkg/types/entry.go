@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/SeerUK/tid/pkg/xtime"
 	"github.com/SeerUK/tid/proto"
 )
 
@@ -31,14 +32,29 @@ type Entry struct {
 	Duration time.Duration
 	// Whether or not this entry's timer is running.
 	IsRunning bool
+	// The amount of time this entry was planned to be tracked for, or 0 if it's not timeboxed.
+	Planned time.Duration
+	// The amount of break time to record once the planned duration has been reached.
+	PlannedBreak time.Duration
+	// The hash of the entry this entry continues from a previous day, if it was split at the end of
+	// that day.
+	Continues string
+	// The time that this entry's timer was last started.
+	Started time.Time
+	// The amount of time that had been logged against this entry when it's timer was last started.
+	StartedDuration time.Duration
+	// The amount of time added to the duration by the most recent call to UpdateDuration, i.e. how
+	// long the timer had been running without being observed. This is not persisted.
+	Elapsed time.Duration
 }
 
-// NewEntry creates a new instance of Entry, with a new random hash, and dates set.
-func NewEntry() Entry {
+// NewEntry creates a new instance of Entry, with a new random hash, and dates set to the given
+// current time.
+func NewEntry(now time.Time) Entry {
 	return Entry{
 		Hash:    createHash(),
-		Created: time.Now(),
-		Updated: time.Now(),
+		Created: now,
+		Updated: now,
 	}
 }
 
@@ -46,34 +62,134 @@ func NewEntry() Entry {
 func (e *Entry) FromMessage(message *proto.TrackingEntry) {
 	e.Timesheet = message.Timesheet
 	e.Hash = message.Key
-	e.Created = time.Unix(int64(message.Created), 0)
-	e.Updated = time.Unix(int64(message.Updated), 0)
+	e.Created = time.Unix(0, message.CreatedNanos)
+	e.Updated = time.Unix(0, message.UpdatedNanos)
+
+	location := xtime.LoadZone(message.Zone, int(message.ZoneOffset), e.Created)
+
+	e.Created = e.Created.In(location)
+	e.Updated = e.Updated.In(location)
 	e.Note = message.Note
-	e.Duration = time.Duration(message.Duration) * time.Second
+	e.Duration = time.Duration(message.DurationNanos)
+	e.Planned = time.Duration(message.PlannedNanos)
+	e.PlannedBreak = time.Duration(message.PlannedBreakNanos)
+	e.Continues = message.Continues
+	e.StartedDuration = time.Duration(message.StartedDurationNanos)
+
+	if message.StartedNanos != 0 {
+		e.Started = time.Unix(0, message.StartedNanos).In(location)
+	}
 }
 
 // ToMessage converts this Entry into a `proto.TrackingEntry`.
 func (e *Entry) ToMessage() *proto.TrackingEntry {
+	zone, offset := xtime.ZoneName(e.Created)
+
+	var started int64
+	if !e.Started.IsZero() {
+		started = e.Started.UnixNano()
+	}
+
 	return &proto.TrackingEntry{
-		Key:       e.Hash,
-		Timesheet: e.Timesheet,
-		Note:      e.Note,
-		Created:   uint64(e.Created.Unix()),
-		Updated:   uint64(e.Updated.Unix()),
-		Duration:  uint64(e.Duration.Seconds()),
+		Key:                  e.Hash,
+		Timesheet:            e.Timesheet,
+		Note:                 e.Note,
+		CreatedNanos:         e.Created.UnixNano(),
+		UpdatedNanos:         e.Updated.UnixNano(),
+		DurationNanos:        int64(e.Duration),
+		PlannedNanos:         int64(e.Planned),
+		PlannedBreakNanos:    int64(e.PlannedBreak),
+		Continues:            e.Continues,
+		Zone:                 zone,
+		ZoneOffset:           int32(offset),
+		StartedNanos:         started,
+		StartedDurationNanos: int64(e.StartedDuration),
 	}
 }
 
-// UpdateDuration adds the difference between the time this entry was last stopped and now to the
-// duration. This also updates `Entry.Updated`.
-func (e *Entry) UpdateDuration() {
-	diff := time.Now().Sub(e.Updated)
+// MarkStarted records that this entry's timer was started at the given time, with the time
+// currently logged against it.
+func (e *Entry) MarkStarted(now time.Time) {
+	e.Started = now
+	e.StartedDuration = e.Duration
+}
+
+// LastStarted returns when this entry's timer was last started, and how much time had been logged
+// against it then. Entries that haven't recorded when they were started are treated as if they
+// were started when they were last observed.
+func (e Entry) LastStarted() (time.Time, time.Duration) {
+	if e.Started.IsZero() {
+		return e.Updated.Add(-e.Elapsed), e.Duration - e.Elapsed
+	}
+
+	return e.Started, e.StartedDuration
+}
+
+// UpdateDuration adds the difference between the time this entry was last stopped and the given
+// current time to the duration. This also updates `Entry.Updated`.
+func (e *Entry) UpdateDuration(now time.Time) {
+	e.Elapsed = now.Sub(e.Updated)
+	e.Duration = e.Duration + e.Elapsed
+	e.Updated = now
+}
+
+// Truncate returns a copy of this Entry with its times and durations rounded down to a multiple of
+// the given precision, for output where the stored precision would only get in the way.
+func (e Entry) Truncate(precision time.Duration) Entry {
+	e.Created = e.Created.Truncate(precision)
+	e.Updated = e.Updated.Truncate(precision)
+	e.Duration = e.Duration.Truncate(precision)
+	e.Planned = e.Planned.Truncate(precision)
+	e.PlannedBreak = e.PlannedBreak.Truncate(precision)
+	e.Started = e.Started.Truncate(precision)
+	e.StartedDuration = e.StartedDuration.Truncate(precision)
+	e.Elapsed = e.Elapsed.Truncate(precision)
+
+	return e
+}
+
+// IsIdle returns true if this entry's timer is running, and had been running without being observed
+// for longer than the given maximum duration. A maximum of 0 or less disables idle detection.
+func (e Entry) IsIdle(max time.Duration) bool {
+	return e.IsRunning && max > 0 && e.Elapsed > max
+}
+
+// IsTimeboxed returns true if this entry was planned to be tracked for a set duration.
+func (e Entry) IsTimeboxed() bool {
+	return e.Planned > 0
+}
+
+// HasExpired returns true if this entry is timeboxed, and has been tracked for at least it's
+// planned duration.
+func (e Entry) HasExpired() bool {
+	return e.IsTimeboxed() && e.Duration >= e.Planned
+}
+
+// Remaining returns how much of this entry's planned duration is left to track.
+func (e Entry) Remaining() time.Duration {
+	if !e.IsTimeboxed() || e.Duration >= e.Planned {
+		return 0
+	}
+
+	return e.Planned - e.Duration
+}
+
+// Overrun returns how much longer than it's planned duration this entry has been tracked for.
+func (e Entry) Overrun() time.Duration {
+	if !e.HasExpired() {
+		return 0
+	}
+
+	return e.Duration - e.Planned
+}
 
-	// We only care about the seconds, nothing more specific, otherwise output is too long.
-	seconds := e.Duration.Seconds() + diff.Seconds()
+// In returns a copy of this Entry with it's times in the given time zone, rather than the one it
+// was created in.
+func (e Entry) In(location *time.Location) Entry {
+	e.Created = e.Created.In(location)
+	e.Updated = e.Updated.In(location)
 
-	e.Duration = time.Duration(seconds) * time.Second
-	e.Updated = time.Now()
+	return e
 }
 
 // ShortHash returns a shortened version of this Entry's hash.
@@ -0,0 +1,269 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// filterOperators are the operators that can be used in an EntryFilter condition. Longer operators
+// come first, so that they're matched before the operators they start with.
+var filterOperators = []string{"!=", "!~", "<=", ">=", "=", "~", "<", ">"}
+
+// filterFieldOperators maps the fields that entries can be filtered by to the operators that can
+// be used with them.
+var filterFieldOperators = map[string][]string{
+	"note":     {"=", "!=", "~", "!~"},
+	"hash":     {"=", "!="},
+	"date":     {"=", "!=", "<", "<=", ">", ">="},
+	"duration": {"=", "!=", "<", "<=", ">", ">="},
+	"running":  {"=", "!="},
+}
+
+// EntryFilter is a set of conditions that entries can be matched against, e.g. to find entries to
+// update or delete in bulk. An entry matches a filter if it matches all of it's conditions, so an
+// empty filter matches every entry.
+type EntryFilter struct {
+	conditions []entryCondition
+}
+
+// entryCondition is a single condition in an EntryFilter, comparing a field of an entry with a
+// value.
+type entryCondition struct {
+	field    string
+	operator string
+	value    string
+	pattern  *regexp.Regexp
+	duration time.Duration
+	running  bool
+}
+
+// ParseEntryFilter attempts to parse the given string as an EntryFilter. Filters are made of one or
+// more conditions, separated by "and", e.g. `note~"JIRA-12" and duration>1h`. Each condition
+// compares a field (note, hash, date, duration, or running) with a value, using one of the
+// operators =, !=, <, <=, >, >=, or ~ and !~ to match a note against a regular expression. Values
+// containing spaces must be quoted.
+func ParseEntryFilter(text string) (EntryFilter, error) {
+	var filter EntryFilter
+
+	rest := strings.TrimSpace(text)
+
+	for rest != "" {
+		if len(filter.conditions) > 0 {
+			fields := strings.Fields(rest)
+			if len(fields) == 0 || (strings.ToLower(fields[0]) != "and" && fields[0] != "&&") {
+				return filter, fmt.Errorf("types: Invalid EntryFilter '%s', expected 'and' before '%s'", text, rest)
+			}
+
+			rest = strings.TrimSpace(rest[len(fields[0]):])
+		}
+
+		field, operator, value, remaining, err := parseEntryCondition(rest)
+		if err != nil {
+			return filter, fmt.Errorf("types: Invalid EntryFilter '%s': %v", text, err)
+		}
+
+		if err := filter.Add(field, operator, value); err != nil {
+			return filter, err
+		}
+
+		rest = strings.TrimSpace(remaining)
+	}
+
+	return filter, nil
+}
+
+// parseEntryCondition reads a single condition from the start of the given string, returning it's
+// parts, and the rest of the string after it.
+func parseEntryCondition(text string) (string, string, string, string, error) {
+	end := strings.IndexFunc(text, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+
+	if end <= 0 {
+		return "", "", "", "", fmt.Errorf("expected a field at '%s'", text)
+	}
+
+	field := strings.ToLower(text[:end])
+	rest := strings.TrimSpace(text[end:])
+
+	operator := ""
+
+	for _, candidate := range filterOperators {
+		if strings.HasPrefix(rest, candidate) {
+			operator = candidate
+			break
+		}
+	}
+
+	if operator == "" {
+		return "", "", "", "", fmt.Errorf("expected an operator after '%s'", field)
+	}
+
+	rest = strings.TrimSpace(rest[len(operator):])
+
+	if strings.HasPrefix(rest, `"`) {
+		for i := 1; i < len(rest); i++ {
+			if rest[i] == '\\' {
+				i++
+				continue
+			}
+
+			if rest[i] == '"' {
+				value, err := strconv.Unquote(rest[:i+1])
+				if err != nil {
+					return "", "", "", "", fmt.Errorf("invalid quoted value %s", rest[:i+1])
+				}
+
+				return field, operator, value, rest[i+1:], nil
+			}
+		}
+
+		return "", "", "", "", fmt.Errorf("unterminated quoted value %s", rest)
+	}
+
+	end = strings.IndexAny(rest, " \t")
+	if end < 0 {
+		end = len(rest)
+	}
+
+	if end == 0 {
+		return "", "", "", "", fmt.Errorf("expected a value after '%s%s'", field, operator)
+	}
+
+	return field, operator, rest[:end], rest[end:], nil
+}
+
+// Add adds a condition to this filter, comparing the given field of entries with the given value,
+// using the given operator.
+func (f *EntryFilter) Add(field string, operator string, value string) error {
+	operators, ok := filterFieldOperators[field]
+	if !ok {
+		return fmt.Errorf("types: Unknown EntryFilter field '%s'", field)
+	}
+
+	if !containsString(operators, operator) {
+		return fmt.Errorf("types: Operator '%s' can't be used with EntryFilter field '%s'", operator, field)
+	}
+
+	condition := entryCondition{
+		field:    field,
+		operator: operator,
+		value:    value,
+	}
+
+	var err error
+
+	switch {
+	case field == "note" && strings.HasSuffix(operator, "~"):
+		condition.pattern, err = regexp.Compile(value)
+	case field == "date":
+		_, err = time.Parse(TimesheetKeyDateFmt, value)
+	case field == "duration":
+		condition.duration, err = time.ParseDuration(value)
+	case field == "running":
+		condition.running, err = strconv.ParseBool(value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("types: Invalid EntryFilter value '%s' for field '%s'", value, field)
+	}
+
+	f.conditions = append(f.conditions, condition)
+
+	return nil
+}
+
+// IsEmpty returns true if this filter has no conditions.
+func (f EntryFilter) IsEmpty() bool {
+	return len(f.conditions) == 0
+}
+
+// Matches returns true if the given entry matches all of the conditions in this filter.
+func (f EntryFilter) Matches(entry Entry) bool {
+	for _, condition := range f.conditions {
+		if !condition.matches(entry) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Filter returns the given entries that match this filter, in the same order.
+func (f EntryFilter) Filter(entries []Entry) []Entry {
+	var matched []Entry
+
+	for _, entry := range entries {
+		if f.Matches(entry) {
+			matched = append(matched, entry)
+		}
+	}
+
+	return matched
+}
+
+// matches returns true if the given entry matches this condition.
+func (c entryCondition) matches(entry Entry) bool {
+	switch c.field {
+	case "note":
+		if c.pattern != nil {
+			return c.pattern.MatchString(entry.Note) == (c.operator == "~")
+		}
+
+		return (entry.Note == c.value) == (c.operator == "=")
+	case "hash":
+		matched := len(c.value) >= 7 && strings.HasPrefix(entry.Hash, c.value)
+
+		return matched == (c.operator == "=")
+	case "date":
+		return compare(strings.Compare(entry.Timesheet, c.value), c.operator)
+	case "duration":
+		switch {
+		case entry.Duration < c.duration:
+			return compare(-1, c.operator)
+		case entry.Duration > c.duration:
+			return compare(1, c.operator)
+		}
+
+		return compare(0, c.operator)
+	case "running":
+		return (entry.IsRunning == c.running) == (c.operator == "=")
+	}
+
+	return false
+}
+
+// compare returns true if the result of comparing two values (i.e. -1, 0, or 1) satisfies the given
+// operator.
+func compare(result int, operator string) bool {
+	switch operator {
+	case "=":
+		return result == 0
+	case "!=":
+		return result != 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	}
+
+	return false
+}
+
+// containsString returns true if the given strings contain the given string.
+func containsString(haystack []string, needle string) bool {
+	for _, candidate := range haystack {
+		if candidate == needle {
+			return true
+		}
+	}
+
+	return false
+}
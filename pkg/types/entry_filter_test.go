@@ -0,0 +1,71 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEntryFilter(t *testing.T) {
+	entry := Entry{
+		Timesheet: "2017-03-01",
+		Hash:      "c24543c0a0f7e7c6fd6d8d5f04e5a1d4b2aea7b0",
+		Note:      "JIRA-12: Fix billing",
+		Duration:  90 * time.Minute,
+	}
+
+	tests := []struct {
+		filter  string
+		matches bool
+	}{
+		{``, true},
+		{`note~"JIRA-12"`, true},
+		{`note~"^Fix"`, false},
+		{`note!~jira`, true},
+		{`note~"(?i)jira"`, true},
+		{`note="JIRA-12: Fix billing"`, true},
+		{`note!="JIRA-12: Fix billing"`, false},
+		{`hash=c24543c`, true},
+		{`hash=c24543`, false},
+		{`date=2017-03-01`, true},
+		{`date>2017-03-01`, false},
+		{`date >= 2017-02-28 and date <= 2017-03-01`, true},
+		{`duration>1h`, true},
+		{`duration>1h && duration<=1h30m`, true},
+		{`duration<1h30m`, false},
+		{`running=false`, true},
+		{`note~JIRA and running=true`, false},
+		{`note~"say \"hi\""`, false},
+	}
+
+	for _, test := range tests {
+		filter, err := ParseEntryFilter(test.filter)
+		if err != nil {
+			t.Errorf("unexpected error parsing filter %q: %v", test.filter, err)
+			continue
+		}
+
+		if matches := filter.Matches(entry); matches != test.matches {
+			t.Errorf("expected filter %q to match %t, got %t", test.filter, test.matches, matches)
+		}
+	}
+
+	invalid := []string{
+		`note`,
+		`note~`,
+		`notes=x`,
+		`note<x`,
+		`note~"(unclosed"`,
+		`note~"unterminated`,
+		`date=yesterday`,
+		`duration>long`,
+		`running=maybe`,
+		`note=x or note=y`,
+		`note=x and`,
+	}
+
+	for _, text := range invalid {
+		if _, err := ParseEntryFilter(text); err == nil {
+			t.Errorf("expected an error parsing filter %q", text)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package types
+
+import (
+	"time"
+
+	"github.com/SeerUK/tid/proto"
+)
+
+const (
+	// RevisionCreate is the action of a revision that created an entry.
+	RevisionCreate = "create"
+	// RevisionStart is the action of a revision that started tracking a new entry.
+	RevisionStart = "start"
+	// RevisionResume is the action of a revision that resumed tracking an entry.
+	RevisionResume = "resume"
+	// RevisionStop is the action of a revision that stopped tracking an entry.
+	RevisionStop = "stop"
+	// RevisionSplit is the action of a revision that stopped tracking an entry at the end of it's
+	// day, and continued it in another entry.
+	RevisionSplit = "split"
+	// RevisionIdle is the action of a revision that resolved idle time tracked on an entry.
+	RevisionIdle = "resolve idle"
+	// RevisionUpdateDuration is the action of a revision that changed an entry's duration.
+	RevisionUpdateDuration = "update duration"
+	// RevisionUpdateNote is the action of a revision that changed an entry's note.
+	RevisionUpdateNote = "update note"
+	// RevisionUpdateCreated is the action of a revision that changed when an entry was created.
+	RevisionUpdateCreated = "update created"
+	// RevisionMove is the action of a revision that moved an entry onto another timesheet.
+	RevisionMove = "move"
+	// RevisionUndo is the action of a revision that undid a change made to an entry.
+	RevisionUndo = "undo"
+	// RevisionRedo is the action of a revision that redid a change made to an entry, after it had
+	// been undone.
+	RevisionRedo = "redo"
+)
+
+// EntryLog represents the history of changes made to an entry. Revisions are only ever appended to
+// it, so that it shows how an entry came to be how it is.
+type EntryLog struct {
+	// The hash of the entry this log belongs to.
+	Entry string
+	// The revisions made to the entry, oldest first.
+	Revisions []EntryRevision
+}
+
+// EntryRevision represents a single change made to an entry.
+type EntryRevision struct {
+	// The time that this change was made.
+	Created time.Time
+	// The name of the user who made this change.
+	Author string
+	// What was done to the entry.
+	Action string
+	// The note of the entry before this change.
+	PreviousNote string
+	// The note of the entry after this change.
+	Note string
+	// The duration of the entry before this change.
+	PreviousDuration time.Duration
+	// The duration of the entry after this change.
+	Duration time.Duration
+}
+
+// NewEntryLog creates a new, empty, instance of EntryLog for the entry with the given hash.
+func NewEntryLog(hash string) EntryLog {
+	return EntryLog{
+		Entry: hash,
+	}
+}
+
+// FromMessage reads a `proto.TrackingEntryLog` message into this EntryLog.
+func (l *EntryLog) FromMessage(message *proto.TrackingEntryLog) {
+	l.Entry = message.Entry
+
+	for _, revision := range message.Revisions {
+		l.Revisions = append(l.Revisions, EntryRevision{
+			Created:          time.Unix(0, revision.CreatedNanos),
+			Author:           revision.Author,
+			Action:           revision.Action,
+			PreviousNote:     revision.PreviousNote,
+			Note:             revision.Note,
+			PreviousDuration: time.Duration(revision.PreviousDurationNanos),
+			Duration:         time.Duration(revision.DurationNanos),
+		})
+	}
+}
+
+// ToMessage converts this EntryLog into a `proto.TrackingEntryLog`.
+func (l *EntryLog) ToMessage() *proto.TrackingEntryLog {
+	message := proto.TrackingEntryLog{
+		Entry: l.Entry,
+	}
+
+	for _, revision := range l.Revisions {
+		message.Revisions = append(message.Revisions, &proto.TrackingEntryRevision{
+			CreatedNanos:          revision.Created.UnixNano(),
+			Author:                revision.Author,
+			Action:                revision.Action,
+			PreviousNote:          revision.PreviousNote,
+			Note:                  revision.Note,
+			PreviousDurationNanos: int64(revision.PreviousDuration),
+			DurationNanos:         int64(revision.Duration),
+		})
+	}
+
+	return &message
+}
+
+// Append adds a revision to the end of this log.
+func (l *EntryLog) Append(revision EntryRevision) {
+	l.Revisions = append(l.Revisions, revision)
+}
+
+// NewEntryRevision creates a new instance of EntryRevision, recording the given action being done
+// by the given author at the given current time, which changed the given entry from previous to
+// current.
+func NewEntryRevision(action string, previous Entry, current Entry, author string, now time.Time) EntryRevision {
+	return EntryRevision{
+		Created:          now,
+		Author:           author,
+		Action:           action,
+		PreviousNote:     previous.Note,
+		Note:             current.Note,
+		PreviousDuration: previous.Duration,
+		Duration:         current.Duration,
+	}
+}
+
+// IsDurationChange returns true if this revision changed the duration of the entry.
+func (r EntryRevision) IsDurationChange() bool {
+	return r.PreviousDuration != r.Duration
+}
+
+// IsNoteChange returns true if this revision changed the note of the entry.
+func (r EntryRevision) IsNoteChange() bool {
+	return r.PreviousNote != r.Note
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IdleAction is an "enum" of the different things that can be done with the time logged against a
+// timer that has been left running for longer than the configured maximum.
+type IdleAction int
+
+// All possible idle actions.
+const (
+	// IdleCap keeps the time, up to the configured maximum.
+	IdleCap IdleAction = iota
+	// IdleKeep keeps all of the time.
+	IdleKeep
+	// IdleDiscard discards all of the time logged since the timer was last observed.
+	IdleDiscard
+)
+
+var idleActions = map[string]IdleAction{
+	"cap":     IdleCap,
+	"keep":    IdleKeep,
+	"discard": IdleDiscard,
+}
+
+// ParseIdleAction attempts to parse the given string as an IdleAction.
+func ParseIdleAction(text string) (IdleAction, error) {
+	text = strings.ToLower(text)
+
+	action, ok := idleActions[text]
+	if !ok {
+		return action, fmt.Errorf("types: Invalid IdleAction '%s'", text)
+	}
+
+	return action, nil
+}
+
+// UnmarshalTOML takes a raw TOML idle action value and attempts to parse the value as an
+// IdleAction. The value passed to this method should be a byte array of a quoted string (i.e. the
+// raw TOML value), the method will remove the quotes.
+func (a *IdleAction) UnmarshalTOML(bytes []byte) error {
+	text, err := strconv.Unquote(string(bytes))
+	if err != nil {
+		return err
+	}
+
+	action, err := ParseIdleAction(text)
+	if err != nil {
+		return err
+	}
+
+	*a = action
+
+	return nil
+}
+
+// String returns the name of this IdleAction.
+func (a IdleAction) String() string {
+	for name, action := range idleActions {
+		if action == a {
+			return name
+		}
+	}
+
+	return ""
+}
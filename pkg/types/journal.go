@@ -0,0 +1,152 @@
+package types
+
+import (
+	"time"
+
+	"github.com/SeerUK/tid/proto"
+)
+
+// Journal represents the operations that have changed data, so that they can be undone and redone.
+type Journal struct {
+	// Operations is an array of the keys of the operations in the journal, oldest first.
+	Operations []string
+	// Position is the number of operations in the journal that have not been undone. Operations
+	// after this position can be redone.
+	Position int
+}
+
+// JournalOperation represents a single operation that changed data.
+type JournalOperation struct {
+	// The key of this operation.
+	Key string
+	// A description of what this operation did.
+	Description string
+	// The time that this operation happened.
+	Created time.Time
+	// The changes this operation made, in the order they were first made.
+	Changes []JournalChange
+	// The entries changed by this operation, as they were after it, or before it if it deleted
+	// them. This is not persisted.
+	Entries []Entry
+}
+
+// JournalChange represents a change made to a single key, or to a bucket if Key is empty.
+type JournalChange struct {
+	// The bucket that was changed.
+	Bucket string
+	// The key that was changed.
+	Key string
+	// The value before the change.
+	Before []byte
+	// The value after the change.
+	After []byte
+	// Whether the key or bucket was created by the change, i.e. there is no value before it.
+	Created bool
+	// Whether the key or bucket was deleted by the change, i.e. there is no value after it.
+	Deleted bool
+}
+
+// NewJournal creates a new instance of Journal.
+func NewJournal() Journal {
+	return Journal{}
+}
+
+// FromMessage reads a `proto.SysJournal` message into this Journal.
+func (j *Journal) FromMessage(message *proto.SysJournal) {
+	j.Operations = message.Operations
+	j.Position = int(message.Position)
+}
+
+// ToMessage converts this Journal into a `proto.SysJournal`.
+func (j *Journal) ToMessage() *proto.SysJournal {
+	return &proto.SysJournal{
+		Operations: j.Operations,
+		Position:   uint32(j.Position),
+	}
+}
+
+// Push adds the operation with the given key after the current position, discarding any operations
+// that could have been redone. The keys of the discarded operations are returned.
+func (j *Journal) Push(key string) []string {
+	discarded := append([]string{}, j.Operations[j.Position:]...)
+
+	j.Operations = append(j.Operations[:j.Position], key)
+	j.Position = len(j.Operations)
+
+	return discarded
+}
+
+// Trim removes the oldest operations, so that at most the given number remain. The keys of the
+// removed operations are returned.
+func (j *Journal) Trim(size int) []string {
+	if len(j.Operations) <= size {
+		return nil
+	}
+
+	excess := len(j.Operations) - size
+	removed := append([]string{}, j.Operations[:excess]...)
+
+	j.Operations = j.Operations[excess:]
+	j.Position -= excess
+
+	if j.Position < 0 {
+		j.Position = 0
+	}
+
+	return removed
+}
+
+// NewJournalOperation creates a new instance of JournalOperation, with a new random key, and the
+// given description, created at the given current time.
+func NewJournalOperation(description string, now time.Time) JournalOperation {
+	return JournalOperation{
+		Key:         createHash(),
+		Description: description,
+		Created:     now,
+	}
+}
+
+// FromMessage reads a `proto.SysJournalOperation` message into this JournalOperation.
+func (o *JournalOperation) FromMessage(message *proto.SysJournalOperation) {
+	o.Key = message.Key
+	o.Description = message.Description
+	o.Created = time.Unix(0, message.CreatedNanos)
+
+	for _, change := range message.Changes {
+		o.Changes = append(o.Changes, JournalChange{
+			Bucket:  change.Bucket,
+			Key:     change.Key,
+			Before:  change.Before,
+			After:   change.After,
+			Created: change.Created,
+			Deleted: change.Deleted,
+		})
+	}
+}
+
+// ToMessage converts this JournalOperation into a `proto.SysJournalOperation`.
+func (o *JournalOperation) ToMessage() *proto.SysJournalOperation {
+	message := proto.SysJournalOperation{
+		Key:          o.Key,
+		Description:  o.Description,
+		CreatedNanos: o.Created.UnixNano(),
+	}
+
+	for _, change := range o.Changes {
+		message.Changes = append(message.Changes, &proto.SysJournalChange{
+			Bucket:  change.Bucket,
+			Key:     change.Key,
+			Before:  change.Before,
+			After:   change.After,
+			Created: change.Created,
+			Deleted: change.Deleted,
+		})
+	}
+
+	return &message
+}
+
+// IsBucketChange returns true if this change was made to a whole bucket, rather than a key.
+func (c JournalChange) IsBucketChange() bool {
+	return c.Key == ""
+}
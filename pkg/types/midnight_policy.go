@@ -0,0 +1,67 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MidnightPolicy is an "enum" of the different ways time tracked by a timer that runs past the end
+// of the day can be attributed to timesheets.
+type MidnightPolicy int
+
+// All possible midnight policies.
+const (
+	// MidnightKeep keeps all of the time on the timesheet the entry was started on.
+	MidnightKeep MidnightPolicy = iota
+	// MidnightSplit splits the time at the end of each day, continuing the entry on the next
+	// day's timesheet.
+	MidnightSplit
+)
+
+var midnightPolicies = map[string]MidnightPolicy{
+	"keep":  MidnightKeep,
+	"split": MidnightSplit,
+}
+
+// ParseMidnightPolicy attempts to parse the given string as a MidnightPolicy.
+func ParseMidnightPolicy(text string) (MidnightPolicy, error) {
+	text = strings.ToLower(text)
+
+	policy, ok := midnightPolicies[text]
+	if !ok {
+		return policy, fmt.Errorf("types: Invalid MidnightPolicy '%s'", text)
+	}
+
+	return policy, nil
+}
+
+// UnmarshalTOML takes a raw TOML midnight policy value and attempts to parse the value as a
+// MidnightPolicy. The value passed to this method should be a byte array of a quoted string (i.e.
+// the raw TOML value), the method will remove the quotes.
+func (p *MidnightPolicy) UnmarshalTOML(bytes []byte) error {
+	text, err := strconv.Unquote(string(bytes))
+	if err != nil {
+		return err
+	}
+
+	policy, err := ParseMidnightPolicy(text)
+	if err != nil {
+		return err
+	}
+
+	*p = policy
+
+	return nil
+}
+
+// String returns the name of this MidnightPolicy.
+func (p MidnightPolicy) String() string {
+	for name, policy := range midnightPolicies {
+		if policy == p {
+			return name
+		}
+	}
+
+	return ""
+}
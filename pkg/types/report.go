@@ -0,0 +1,206 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+// Report represents an aggregated view of the timesheets within a date range. Durations on a
+// Report have any configured rounding applied, they are intended for output only.
+type Report struct {
+	// The start date of the report.
+	Start time.Time
+	// The end date of the report.
+	End time.Time
+	// The name of the workspace the report is for.
+	Workspace string
+	// The timesheets within the report's date range, with their entries.
+	Timesheets []ReportTimesheet
+	// All of the entries within the report's date range.
+	Entries []Entry
+	// The total amount of time logged within the report's date range.
+	Duration time.Duration
+	// The amount of time expected to be logged within the report's date range.
+	Target time.Duration
+	// The number of entries within the report's date range.
+	EntryCount int
+	// The number of timesheets within the report's date range.
+	TimesheetCount int
+}
+
+// ReportTimesheet represents a single timesheet within a Report, with it's subtotal.
+type ReportTimesheet struct {
+	// The date of the timesheet.
+	Key string
+	// The date of the timesheet, as a time.
+	Date time.Time
+	// The entries that belong to this timesheet.
+	Entries []Entry
+	// The total amount of time logged on this timesheet.
+	Duration time.Duration
+	// The amount of time expected to be logged on this timesheet.
+	Target time.Duration
+	// The number of entries on this timesheet.
+	EntryCount int
+	// The reason this timesheet's date is a non-working day, if it is one.
+	NonWorking string
+}
+
+// NewReport creates a new Report for the given date range from the given timesheets, using the
+// given configuration to calculate durations and targets. Non-working days in the given calendar
+// are not expected to be worked.
+func NewReport(start time.Time, end time.Time, workspace string, sheets []Timesheet, config Config, calendar Calendar) Report {
+	display := config.Display
+	targets := config.Targets.ForWorkspace(workspace)
+
+	report := Report{
+		Start:     start,
+		End:       end,
+		Workspace: workspace,
+		Target:    targets.Expected(start, end, display.FirstWeekday.TimeWeekday(), calendar),
+	}
+
+	for _, sheet := range sheets {
+		date, _ := time.Parse(TimesheetKeyDateFmt, sheet.Key)
+
+		if display.Timezone.IsSet() {
+			sheet.Entries = entriesIn(sheet.Entries, display.Timezone.TimeLocation())
+		}
+
+		rts := ReportTimesheet{
+			Key:        sheet.Key,
+			Date:       date,
+			Entries:    sheet.Entries,
+			Duration:   display.TotalDuration(sheet.Entries),
+			Target:     targets.ExpectedOn(date, display.FirstWeekday.TimeWeekday(), calendar),
+			EntryCount: len(sheet.Entries),
+		}
+
+		if day, ok := calendar.Find(sheet.Key); ok {
+			rts.NonWorking = day.Reason
+
+			if rts.NonWorking == "" {
+				rts.NonWorking = "non-working day"
+			}
+		}
+
+		report.Timesheets = append(report.Timesheets, rts)
+
+		report.Entries = append(report.Entries, sheet.Entries...)
+	}
+
+	report.Duration = display.TotalDuration(report.Entries)
+	report.EntryCount = len(report.Entries)
+	report.TimesheetCount = len(report.Timesheets)
+
+	return report
+}
+
+// DateRange returns a human-readable representation of the report's date range.
+func (r Report) DateRange() string {
+	if r.Start.Equal(r.End) {
+		return r.End.Format(xtime.DateFmt)
+	}
+
+	return fmt.Sprintf("%s to %s", r.Start.Format(xtime.DateFmt), r.End.Format(xtime.DateFmt))
+}
+
+// Remaining returns how much time is left to log to reach the report's target.
+func (r Report) Remaining() time.Duration {
+	return remaining(r.Duration, r.Target)
+}
+
+// Overtime returns how much time has been logged beyond the report's target.
+func (r Report) Overtime() time.Duration {
+	return overtime(r.Duration, r.Target)
+}
+
+// Truncate returns a copy of this Report with its times and durations, and those of its timesheets
+// and entries, rounded down to a multiple of the given precision.
+func (r Report) Truncate(precision time.Duration) Report {
+	var sheets []ReportTimesheet
+
+	for _, sheet := range r.Timesheets {
+		sheets = append(sheets, sheet.Truncate(precision))
+	}
+
+	r.Timesheets = sheets
+	r.Entries = truncateEntries(r.Entries, precision)
+	r.Duration = r.Duration.Truncate(precision)
+	r.Target = r.Target.Truncate(precision)
+
+	return r
+}
+
+// Label returns the timesheet's date, annotated with the reason it is a non-working day if it is
+// one.
+func (t ReportTimesheet) Label() string {
+	if t.NonWorking == "" {
+		return t.Key
+	}
+
+	return fmt.Sprintf("%s (%s)", t.Key, t.NonWorking)
+}
+
+// Remaining returns how much time is left to log to reach the timesheet's target.
+func (t ReportTimesheet) Remaining() time.Duration {
+	return remaining(t.Duration, t.Target)
+}
+
+// Overtime returns how much time has been logged beyond the timesheet's target.
+func (t ReportTimesheet) Overtime() time.Duration {
+	return overtime(t.Duration, t.Target)
+}
+
+// Truncate returns a copy of this ReportTimesheet with its durations, and the times and durations of
+// its entries, rounded down to a multiple of the given precision.
+func (t ReportTimesheet) Truncate(precision time.Duration) ReportTimesheet {
+	t.Entries = truncateEntries(t.Entries, precision)
+	t.Duration = t.Duration.Truncate(precision)
+	t.Target = t.Target.Truncate(precision)
+
+	return t
+}
+
+// remaining returns how much of the given target has not been worked yet.
+func remaining(worked time.Duration, target time.Duration) time.Duration {
+	if worked >= target {
+		return 0
+	}
+
+	return target - worked
+}
+
+// overtime returns how much has been worked beyond the given target.
+func overtime(worked time.Duration, target time.Duration) time.Duration {
+	if worked <= target {
+		return 0
+	}
+
+	return worked - target
+}
+
+// entriesIn returns copies of the given entries with their times in the given time zone.
+func entriesIn(entries []Entry, location *time.Location) []Entry {
+	var converted []Entry
+
+	for _, entry := range entries {
+		converted = append(converted, entry.In(location))
+	}
+
+	return converted
+}
+
+// truncateEntries returns copies of the given entries with their times and durations rounded down
+// to a multiple of the given precision.
+func truncateEntries(entries []Entry, precision time.Duration) []Entry {
+	var truncated []Entry
+
+	for _, entry := range entries {
+		truncated = append(truncated, entry.Truncate(precision))
+	}
+
+	return truncated
+}
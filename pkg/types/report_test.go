@@ -0,0 +1,50 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportTruncate(t *testing.T) {
+	created := time.Date(2017, 3, 1, 9, 0, 0, 123456789, time.UTC)
+
+	entry := Entry{
+		Created:  created,
+		Updated:  created.Add(time.Hour),
+		Duration: time.Hour + 123456789,
+		Planned:  30*time.Minute + 1,
+		Started:  created,
+	}
+
+	report := Report{
+		Timesheets: []ReportTimesheet{{Entries: []Entry{entry}, Duration: entry.Duration}},
+		Entries:    []Entry{entry},
+		Duration:   entry.Duration,
+	}.Truncate(time.Second)
+
+	expected := Entry{
+		Created:  time.Date(2017, 3, 1, 9, 0, 0, 0, time.UTC),
+		Updated:  time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC),
+		Duration: time.Hour,
+		Planned:  30 * time.Minute,
+		Started:  time.Date(2017, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	if report.Duration != time.Hour {
+		t.Errorf("expected report duration to be %s, got %s", time.Hour, report.Duration)
+	}
+
+	if report.Timesheets[0].Duration != time.Hour {
+		t.Errorf("expected timesheet duration to be %s, got %s", time.Hour, report.Timesheets[0].Duration)
+	}
+
+	for _, actual := range []Entry{report.Entries[0], report.Timesheets[0].Entries[0]} {
+		if actual != expected {
+			t.Errorf("expected entry to be %+v, got %+v", expected, actual)
+		}
+	}
+
+	if entry.Duration != time.Hour+123456789 {
+		t.Errorf("expected original entry to be unchanged, got duration %s", entry.Duration)
+	}
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ResumePolicy is an "enum" of the different ways an entry from a previous day can be resumed.
+type ResumePolicy int
+
+// All possible resume policies.
+const (
+	// ResumeContinue continues the entry in a new entry on the current day's timesheet, leaving the
+	// previous day's timesheet as it was.
+	ResumeContinue ResumePolicy = iota
+	// ResumeExtend keeps adding time to the entry, on the timesheet it was started on.
+	ResumeExtend
+)
+
+var resumePolicies = map[string]ResumePolicy{
+	"continue": ResumeContinue,
+	"extend":   ResumeExtend,
+}
+
+// ParseResumePolicy attempts to parse the given string as a ResumePolicy.
+func ParseResumePolicy(text string) (ResumePolicy, error) {
+	text = strings.ToLower(text)
+
+	policy, ok := resumePolicies[text]
+	if !ok {
+		return policy, fmt.Errorf("types: Invalid ResumePolicy '%s'", text)
+	}
+
+	return policy, nil
+}
+
+// UnmarshalTOML takes a raw TOML resume policy value and attempts to parse the value as a
+// ResumePolicy. The value passed to this method should be a byte array of a quoted string (i.e. the
+// raw TOML value), the method will remove the quotes.
+func (p *ResumePolicy) UnmarshalTOML(bytes []byte) error {
+	text, err := strconv.Unquote(string(bytes))
+	if err != nil {
+		return err
+	}
+
+	policy, err := ParseResumePolicy(text)
+	if err != nil {
+		return err
+	}
+
+	*p = policy
+
+	return nil
+}
+
+// String returns the name of this ResumePolicy.
+func (p ResumePolicy) String() string {
+	for name, policy := range resumePolicies {
+		if policy == p {
+			return name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SearchQuery matches entries by their notes, either by a substring, or a regular expression.
+type SearchQuery struct {
+	// pattern is the regular expression notes are matched against.
+	pattern *regexp.Regexp
+	// ignoreCase is whether the case of notes is ignored.
+	ignoreCase bool
+}
+
+// NewSearchQuery creates a new SearchQuery for the given query. If regex is true, the query is a
+// regular expression, otherwise it's a substring. If ignoreCase is true, notes match regardless of
+// their case. An empty query would match every entry, so it's rejected.
+func NewSearchQuery(query string, regex bool, ignoreCase bool) (SearchQuery, error) {
+	if strings.TrimSpace(query) == "" {
+		return SearchQuery{}, errors.New("types: The search query must not be empty")
+	}
+
+	expr := query
+	if !regex {
+		expr = regexp.QuoteMeta(query)
+	}
+
+	if ignoreCase {
+		expr = "(?i)" + expr
+	}
+
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return SearchQuery{}, fmt.Errorf("types: Invalid search query '%s'", query)
+	}
+
+	return SearchQuery{
+		pattern:    pattern,
+		ignoreCase: ignoreCase,
+	}, nil
+}
+
+// Match returns true if the given note matches this query, along with the name of the group the
+// match belongs to. The group is the text that matched, or the text matched by the first capturing
+// group in a regular expression that has one. When ignoring case, groups are lower case, so that
+// the same text in different cases belongs to the same group.
+func (q SearchQuery) Match(note string) (string, bool) {
+	match := q.pattern.FindStringSubmatch(note)
+	if match == nil {
+		return "", false
+	}
+
+	group := match[0]
+	if len(match) > 1 {
+		group = match[1]
+	}
+
+	if q.ignoreCase {
+		group = strings.ToLower(group)
+	}
+
+	return group, true
+}
+
+// SearchResult represents an entry that matched a SearchQuery.
+type SearchResult struct {
+	// The name of the workspace the entry belongs to.
+	Workspace string
+	// The entry that matched.
+	Entry Entry
+	// The name of the group the match belongs to.
+	Group string
+}
+
+// SearchGroup represents the results of a search that belong to the same group, with their total.
+type SearchGroup struct {
+	// The name of this group.
+	Name string
+	// The number of entries in this group.
+	EntryCount int
+	// The date of the earliest timesheet an entry in this group belongs to.
+	First string
+	// The date of the latest timesheet an entry in this group belongs to.
+	Last string
+	// The total amount of time logged against the entries in this group.
+	Duration time.Duration
+}
+
+// NewSearchGroups groups the given search results by their group, in the order each group was
+// first matched.
+func NewSearchGroups(results []SearchResult) []SearchGroup {
+	var groups []SearchGroup
+
+	indexes := make(map[string]int)
+
+	for _, result := range results {
+		index, ok := indexes[result.Group]
+		if !ok {
+			index = len(groups)
+			indexes[result.Group] = index
+
+			groups = append(groups, SearchGroup{
+				Name:  result.Group,
+				First: result.Entry.Timesheet,
+				Last:  result.Entry.Timesheet,
+			})
+		}
+
+		group := &groups[index]
+		group.EntryCount++
+		group.Duration += result.Entry.Duration
+
+		if result.Entry.Timesheet < group.First {
+			group.First = result.Entry.Timesheet
+		}
+
+		if result.Entry.Timesheet > group.Last {
+			group.Last = result.Entry.Timesheet
+		}
+	}
+
+	return groups
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSearchQuery(t *testing.T) {
+	tests := []struct {
+		query      string
+		regex      bool
+		ignoreCase bool
+		note       string
+		group      string
+		matches    bool
+	}{
+		{"billing", false, false, "Billing migration", "", false},
+		{"billing", false, true, "Billing migration", "billing", true},
+		{"JIRA-1.", false, false, "JIRA-12 fix", "", false},
+		{"JIRA-1.", true, false, "JIRA-12 fix", "JIRA-12", true},
+		{`JIRA-(\d+)`, true, false, "Review JIRA-15", "15", true},
+		{`jira-\d+`, true, true, "Review JIRA-15", "jira-15", true},
+	}
+
+	for _, test := range tests {
+		query, err := NewSearchQuery(test.query, test.regex, test.ignoreCase)
+		if err != nil {
+			t.Errorf("unexpected error creating query %q: %v", test.query, err)
+			continue
+		}
+
+		group, matches := query.Match(test.note)
+		if matches != test.matches || group != test.group {
+			t.Errorf("expected %q to match %q: %t (%q), got %t (%q)", test.query, test.note, test.matches, test.group, matches, group)
+		}
+	}
+
+	if _, err := NewSearchQuery("(", true, false); err == nil {
+		t.Error("expected an error creating a query with an invalid regular expression")
+	}
+
+	for _, query := range []string{"", "  "} {
+		if _, err := NewSearchQuery(query, false, false); err == nil {
+			t.Errorf("expected an error creating a query from %q", query)
+		}
+	}
+}
+
+func TestNewSearchGroups(t *testing.T) {
+	results := []SearchResult{
+		{Group: "12", Entry: Entry{Timesheet: "2017-03-02", Duration: time.Hour}},
+		{Group: "15", Entry: Entry{Timesheet: "2017-03-03", Duration: time.Minute}},
+		{Group: "12", Entry: Entry{Timesheet: "2017-03-01", Duration: 30 * time.Minute}},
+	}
+
+	groups := NewSearchGroups(results)
+
+	expected := []SearchGroup{
+		{Name: "12", EntryCount: 2, First: "2017-03-01", Last: "2017-03-02", Duration: 90 * time.Minute},
+		{Name: "15", EntryCount: 1, First: "2017-03-03", Last: "2017-03-03", Duration: time.Minute},
+	}
+
+	if len(groups) != len(expected) {
+		t.Fatalf("expected %d groups, got: %+v", len(expected), groups)
+	}
+
+	for i, group := range groups {
+		if group != expected[i] {
+			t.Errorf("expected group %d to be %+v, got %+v", i, expected[i], group)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"time"
 
+	"github.com/SeerUK/tid/pkg/xtime"
 	"github.com/SeerUK/tid/proto"
 )
 
@@ -15,12 +16,15 @@ type Timesheet struct {
 	Key string
 	// An array of entries that belong in this timesheet.
 	Entries []Entry
+	// The time zone this timesheet was created in.
+	Location *time.Location
 }
 
-// NewTimesheet create a new instance of Timesheet.
-func NewTimesheet() Timesheet {
+// NewTimesheet create a new instance of Timesheet, for the date of the given current time.
+func NewTimesheet(now time.Time) Timesheet {
 	return Timesheet{
-		Key: time.Now().Format(TimesheetKeyDateFmt),
+		Key:      now.Format(TimesheetKeyDateFmt),
+		Location: now.Location(),
 	}
 }
 
@@ -28,6 +32,7 @@ func NewTimesheet() Timesheet {
 func (t *Timesheet) FromMessageWithEntries(message *proto.TrackingTimesheet, entries []Entry) {
 	t.Key = message.Key
 	t.Entries = entries
+	t.Location = xtime.LoadZone(message.Zone, int(message.ZoneOffset), t.Date())
 }
 
 // ToMessage converts this Timesheet to a `proto.TrackingTimesheet`.
@@ -38,12 +43,36 @@ func (t *Timesheet) ToMessage() *proto.TrackingTimesheet {
 		entryKeys = append(entryKeys, entry.Hash)
 	}
 
+	zone, offset := xtime.ZoneName(t.Date())
+
 	return &proto.TrackingTimesheet{
-		Key:     t.Key,
-		Entries: entryKeys,
+		Key:        t.Key,
+		Entries:    entryKeys,
+		Zone:       zone,
+		ZoneOffset: int32(offset),
 	}
 }
 
+// Date returns the start of the day this timesheet is for, in the time zone it was created in.
+func (t Timesheet) Date() time.Time {
+	location := t.Location
+	if location == nil {
+		location = time.Local
+	}
+
+	date, _ := time.ParseInLocation(TimesheetKeyDateFmt, t.Key, location)
+
+	return date
+}
+
+// Truncate returns a copy of this Timesheet with the times and durations of its entries rounded
+// down to a multiple of the given precision.
+func (t Timesheet) Truncate(precision time.Duration) Timesheet {
+	t.Entries = truncateEntries(t.Entries, precision)
+
+	return t
+}
+
 // AppendEntry appends a reference to an entry to the timesheet.
 func (t *Timesheet) AppendEntry(entry Entry) {
 	t.Entries = append(t.Entries, entry)
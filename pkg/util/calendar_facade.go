@@ -0,0 +1,85 @@
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/state"
+	"github.com/SeerUK/tid/pkg/types"
+)
+
+// CalendarFacade provides a simpler interface for common calendar-related tasks.
+type CalendarFacade struct {
+	// sysGateway is a SysGateway used for accessing system storage.
+	sysGateway state.SysGateway
+	// journal is a Journal used to record changes, so that they can be undone.
+	journal state.Journal
+}
+
+// NewCalendarFacade creates a new CalendarFacade instance.
+func NewCalendarFacade(sysGateway state.SysGateway, journal state.Journal) *CalendarFacade {
+	return &CalendarFacade{
+		sysGateway: sysGateway,
+		journal:    journal,
+	}
+}
+
+// Add marks the given date as a non-working day, for the given reason. If the date is already a
+// non-working day, the reason is updated.
+func (f *CalendarFacade) Add(date time.Time, reason string) (_ types.CalendarDay, err error) {
+	f.journal.Begin(fmt.Sprintf("Add non-working day %s", date.Format(types.TimesheetKeyDateFmt)))
+	defer endOperation(f.journal, &err)
+
+	day := types.CalendarDay{
+		Date:   date.Format(types.TimesheetKeyDateFmt),
+		Reason: reason,
+	}
+
+	calendar, err := f.sysGateway.FindOrCreateCalendar()
+	if err != nil {
+		return day, err
+	}
+
+	calendar.Add(day)
+
+	return day, f.sysGateway.PersistCalendar(calendar)
+}
+
+// Delete removes the given date from the calendar, making it a working day again.
+func (f *CalendarFacade) Delete(date time.Time) (_ types.CalendarDay, err error) {
+	f.journal.Begin(fmt.Sprintf("Delete non-working day %s", date.Format(types.TimesheetKeyDateFmt)))
+	defer endOperation(f.journal, &err)
+
+	key := date.Format(types.TimesheetKeyDateFmt)
+
+	calendar, err := f.sysGateway.FindOrCreateCalendar()
+	if err != nil {
+		return types.CalendarDay{}, err
+	}
+
+	day, ok := calendar.Find(key)
+	if !ok {
+		return day, fmt.Errorf("util: Date '%s' is not a non-working day", key)
+	}
+
+	calendar.Remove(key)
+
+	return day, f.sysGateway.PersistCalendar(calendar)
+}
+
+// Import adds all of the given days to the calendar as non-working days.
+func (f *CalendarFacade) Import(days []types.CalendarDay) (err error) {
+	f.journal.Begin("Import non-working days")
+	defer endOperation(f.journal, &err)
+
+	calendar, err := f.sysGateway.FindOrCreateCalendar()
+	if err != nil {
+		return err
+	}
+
+	for _, day := range days {
+		calendar.Add(day)
+	}
+
+	return f.sysGateway.PersistCalendar(calendar)
+}
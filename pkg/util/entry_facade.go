@@ -2,11 +2,14 @@ package util
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/SeerUK/tid/pkg/errhandling"
 	"github.com/SeerUK/tid/pkg/state"
 	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
+	protobuf "github.com/golang/protobuf/proto"
 )
 
 // EntryFacade provides a simpler interface for common Entry-related tasks.
@@ -15,19 +18,28 @@ type EntryFacade struct {
 	sysGateway state.SysGateway
 	// trGateway is a TimesheetGateway used for accessing timesheet storage.
 	trGateway state.TrackingGateway
+	// clock is a Clock used to get the current time.
+	clock xtime.Clock
+	// journal is a Journal used to record changes, so that they can be undone.
+	journal state.Journal
 }
 
 // NewEntryFacade creates a new EntryFacade instance.
-func NewEntryFacade(sysGateway state.SysGateway, trackingGateway state.TrackingGateway) *EntryFacade {
+func NewEntryFacade(sysGateway state.SysGateway, trackingGateway state.TrackingGateway, clock xtime.Clock, journal state.Journal) *EntryFacade {
 	return &EntryFacade{
 		sysGateway: sysGateway,
 		trGateway:  trackingGateway,
+		clock:      clock,
+		journal:    journal,
 	}
 }
 
 // Create creates and persists a new entry with the given details.
-func (f *EntryFacade) Create(start time.Time, dur time.Duration, note string) (types.Entry, error) {
-	entry := types.NewEntry()
+func (f *EntryFacade) Create(start time.Time, dur time.Duration, note string) (_ types.Entry, err error) {
+	f.journal.Begin("Create entry")
+	defer endOperation(f.journal, &err)
+
+	entry := types.NewEntry(f.clock.Now())
 
 	sheet, err := f.trGateway.FindOrCreateTimesheet(start.Format(types.TimesheetKeyDateFmt))
 	if err != nil {
@@ -43,6 +55,7 @@ func (f *EntryFacade) Create(start time.Time, dur time.Duration, note string) (t
 	errs := errhandling.NewErrorStack()
 	errs.Add(f.trGateway.PersistTimesheet(sheet))
 	errs.Add(f.trGateway.PersistEntry(entry))
+	errs.Add(recordRevision(f.trGateway, f.clock, types.RevisionCreate, types.Entry{}, entry))
 
 	if !errs.Empty() {
 		return entry, errs.Errors()
@@ -52,7 +65,10 @@ func (f *EntryFacade) Create(start time.Time, dur time.Duration, note string) (t
 }
 
 // UpdateDuration updates an entry with the given hash with the given duration.
-func (f *EntryFacade) UpdateDuration(hash string, duration time.Duration) (types.Entry, error) {
+func (f *EntryFacade) UpdateDuration(hash string, duration time.Duration) (_ types.Entry, err error) {
+	f.journal.Begin("Update entry duration")
+	defer endOperation(f.journal, &err)
+
 	entry, err := f.trGateway.FindEntry(hash)
 	if err != nil {
 		return entry, err
@@ -62,14 +78,18 @@ func (f *EntryFacade) UpdateDuration(hash string, duration time.Duration) (types
 		return entry, errors.New("tracking: Duration cannot be less than 0")
 	}
 
+	previous := entry
 	entry.Duration = duration
 
-	return entry, f.trGateway.PersistEntry(entry)
+	return entry, f.persist(types.RevisionUpdateDuration, previous, entry)
 }
 
 // UpdateDurationByOffset updates an entry with the given hash, offsetting the duration by the given
 // offset duration.
-func (f *EntryFacade) UpdateDurationByOffset(hash string, offset time.Duration) (types.Entry, error) {
+func (f *EntryFacade) UpdateDurationByOffset(hash string, offset time.Duration) (_ types.Entry, err error) {
+	f.journal.Begin("Update entry duration")
+	defer endOperation(f.journal, &err)
+
 	entry, err := f.trGateway.FindEntry(hash)
 	if err != nil {
 		return entry, err
@@ -81,7 +101,7 @@ func (f *EntryFacade) UpdateDurationByOffset(hash string, offset time.Duration)
 	}
 
 	if status.IsRunning && status.Entry == entry.Hash {
-		entry.UpdateDuration()
+		entry.UpdateDuration(f.clock.Now())
 	}
 
 	duration := entry.Duration + offset
@@ -90,19 +110,233 @@ func (f *EntryFacade) UpdateDurationByOffset(hash string, offset time.Duration)
 }
 
 // UpdateNote updates an entry with the given hash with the given note.
-func (f *EntryFacade) UpdateNote(hash string, note string) (types.Entry, error) {
+func (f *EntryFacade) UpdateNote(hash string, note string) (_ types.Entry, err error) {
+	f.journal.Begin("Update entry note")
+	defer endOperation(f.journal, &err)
+
 	entry, err := f.trGateway.FindEntry(hash)
 	if err != nil {
 		return entry, err
 	}
 
+	previous := entry
 	entry.Note = note
 
-	return entry, f.trGateway.PersistEntry(entry)
+	return entry, f.persist(types.RevisionUpdateNote, previous, entry)
+}
+
+// UpdateCreated updates an entry with the given hash with the given created time.
+func (f *EntryFacade) UpdateCreated(hash string, created time.Time) (_ types.Entry, err error) {
+	f.journal.Begin("Update entry created time")
+	defer endOperation(f.journal, &err)
+
+	entry, err := f.trGateway.FindEntry(hash)
+	if err != nil {
+		return entry, err
+	}
+
+	if created.IsZero() {
+		return entry, errors.New("tracking: Created time cannot be empty")
+	}
+
+	previous := entry
+	entry.Created = created
+
+	return entry, f.persist(types.RevisionUpdateCreated, previous, entry)
+}
+
+// UpdateDate moves an entry with the given hash onto the timesheet for the given date.
+func (f *EntryFacade) UpdateDate(hash string, date time.Time) (_ types.Entry, err error) {
+	f.journal.Begin("Move entry")
+	defer endOperation(f.journal, &err)
+
+	entry, err := f.trGateway.FindEntry(hash)
+	if err != nil {
+		return entry, err
+	}
+
+	key := date.Format(types.TimesheetKeyDateFmt)
+	if key == entry.Timesheet {
+		return entry, nil
+	}
+
+	status, err := f.sysGateway.FindOrCreateStatus()
+	if err != nil {
+		return entry, err
+	}
+
+	from, err := f.trGateway.FindOrCreateTimesheet(entry.Timesheet)
+	if err != nil {
+		return entry, err
+	}
+
+	to, err := f.trGateway.FindOrCreateTimesheet(key)
+	if err != nil {
+		return entry, err
+	}
+
+	previous := entry
+	entry.Timesheet = to.Key
+
+	from.RemoveEntry(entry)
+	to.AppendEntry(entry)
+
+	errs := errhandling.NewErrorStack()
+	errs.Add(f.trGateway.PersistTimesheet(from))
+	errs.Add(f.trGateway.PersistTimesheet(to))
+
+	if status.Entry == entry.Hash {
+		status.Timesheet = to.Key
+		errs.Add(f.sysGateway.PersistStatus(status))
+	}
+
+	errs.Add(f.persist(types.RevisionMove, previous, entry))
+
+	return entry, errs.Errors()
+}
+
+// Edit applies the changes made to each of the given original entries, in the edited entry at the
+// same index, as one change. Only the date, created time, duration, and note of an entry can be
+// edited. Every edited entry is checked before anything is changed, so nothing is changed if any of
+// them are invalid, or if any of the original entries have been changed since they were read. The
+// entries that were changed are returned, as they are after the changes.
+func (f *EntryFacade) Edit(originals []types.Entry, edited []types.Entry) (_ []types.Entry, err error) {
+	var changed []types.Entry
+
+	if len(originals) != len(edited) {
+		return changed, errors.New("tracking: Every original entry must have an edited entry")
+	}
+
+	for i, original := range originals {
+		if err := f.validateEdit(original, edited[i]); err != nil {
+			return changed, err
+		}
+	}
+
+	f.journal.Begin("Edit entries")
+	defer endOperation(f.journal, &err)
+
+	for i, original := range originals {
+		entry, err := f.edit(original, edited[i])
+		if err != nil {
+			return changed, err
+		}
+
+		if entry.Hash != "" {
+			changed = append(changed, entry)
+		}
+	}
+
+	return changed, nil
+}
+
+// validateEdit returns an error if the changes made to the given original entry in the given edited
+// entry can't be applied, or if the original entry has been changed since it was read.
+func (f *EntryFacade) validateEdit(original types.Entry, edited types.Entry) error {
+	if edited.Hash != original.Hash {
+		return fmt.Errorf("tracking: Entry '%s' can't be changed to a different entry", original.ShortHash())
+	}
+
+	if _, err := time.Parse(types.TimesheetKeyDateFmt, edited.Timesheet); err != nil {
+		return fmt.Errorf("tracking: Invalid date '%s' for entry '%s'", edited.Timesheet, original.ShortHash())
+	}
+
+	if edited.Created.IsZero() {
+		return fmt.Errorf("tracking: Created time cannot be empty for entry '%s'", original.ShortHash())
+	}
+
+	if edited.Duration < 0 {
+		return fmt.Errorf("tracking: Duration cannot be less than 0 for entry '%s'", original.ShortHash())
+	}
+
+	current, err := f.trGateway.FindEntry(original.Hash)
+	if err != nil {
+		return err
+	}
+
+	if hasChanged(original, current) {
+		return fmt.Errorf("tracking: Entry '%s' has been changed since it was read", original.ShortHash())
+	}
+
+	return nil
+}
+
+// edit applies the changes made to the given original entry in the given edited entry, which must
+// have been checked with validateEdit. If nothing was changed, an empty entry is returned.
+func (f *EntryFacade) edit(original types.Entry, edited types.Entry) (types.Entry, error) {
+	var entry types.Entry
+	var err error
+
+	if edited.Timesheet != original.Timesheet {
+		date, _ := time.Parse(types.TimesheetKeyDateFmt, edited.Timesheet)
+
+		if entry, err = f.UpdateDate(original.Hash, date); err != nil {
+			return entry, err
+		}
+	}
+
+	if !edited.Created.Equal(original.Created) {
+		if entry, err = f.UpdateCreated(original.Hash, edited.Created); err != nil {
+			return entry, err
+		}
+	}
+
+	if edited.Duration != original.Duration {
+		if entry, err = f.UpdateDuration(original.Hash, edited.Duration); err != nil {
+			return entry, err
+		}
+	}
+
+	if edited.Note != original.Note {
+		if entry, err = f.UpdateNote(original.Hash, edited.Note); err != nil {
+			return entry, err
+		}
+	}
+
+	return entry, nil
+}
+
+// FindMatching finds the entries on every timesheet that match the given filter, oldest first. An
+// empty filter would match every entry, so it's rejected, to stop every entry being changed by
+// mistake.
+func (f *EntryFacade) FindMatching(filter types.EntryFilter) ([]types.Entry, error) {
+	var entries []types.Entry
+
+	if filter.IsEmpty() {
+		return entries, errors.New("tracking: The filter must not be empty")
+	}
+
+	sheets, err := f.trGateway.FindTimesheets()
+	if err != nil {
+		return entries, err
+	}
+
+	for _, sheet := range sheets {
+		entries = append(entries, filter.Filter(sheet.Entries)...)
+	}
+
+	return entries, nil
+}
+
+// Log finds an entry with the given hash, and the log of revisions that have been made to it.
+func (f *EntryFacade) Log(hash string) (types.Entry, types.EntryLog, error) {
+	var log types.EntryLog
+
+	entry, err := f.trGateway.FindEntry(hash)
+	if err != nil {
+		return entry, log, err
+	}
+
+	log, err = f.trGateway.FindOrCreateEntryLog(entry.Hash)
+
+	return entry, log, err
 }
 
 // Delete deletes persisted data for a timesheet entry with the given hash.
-func (f *EntryFacade) Delete(hash string) (types.Entry, error) {
+func (f *EntryFacade) Delete(hash string) (_ types.Entry, err error) {
+	f.journal.Begin("Delete entry")
+	defer endOperation(f.journal, &err)
+
 	entry, err := f.trGateway.FindEntry(hash)
 	if err != nil {
 		return entry, err
@@ -141,3 +375,56 @@ func (f *EntryFacade) Delete(hash string) (types.Entry, error) {
 
 	return entry, nil
 }
+
+// DeleteMany deletes persisted data for each of the given timesheet entries, as one change. Every
+// entry is checked before anything is deleted, so nothing is deleted if any of them are missing, or
+// have been changed since they were read.
+func (f *EntryFacade) DeleteMany(originals []types.Entry) (_ []types.Entry, err error) {
+	var entries []types.Entry
+
+	for _, original := range originals {
+		current, err := f.trGateway.FindEntry(original.Hash)
+		if err != nil {
+			return entries, err
+		}
+
+		if hasChanged(original, current) {
+			return entries, fmt.Errorf("tracking: Entry '%s' has been changed since it was read", original.ShortHash())
+		}
+	}
+
+	f.journal.Begin("Delete entries")
+	defer endOperation(f.journal, &err)
+
+	for _, original := range originals {
+		entry, err := f.Delete(original.Hash)
+		if err != nil {
+			return entries, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// persist persists the given entry, recording that the given action changed it from previous in
+// it's log.
+func (f *EntryFacade) persist(action string, previous types.Entry, entry types.Entry) error {
+	errs := errhandling.NewErrorStack()
+	errs.Add(f.trGateway.PersistEntry(entry))
+	errs.Add(recordRevision(f.trGateway, f.clock, action, previous, entry))
+
+	return errs.Errors()
+}
+
+// hasChanged returns true if the given current entry has been changed since it was read as the given
+// original entry. Time tracked by a running timer in the meantime isn't a change.
+func hasChanged(original types.Entry, current types.Entry) bool {
+	for _, entry := range []*types.Entry{&original, &current} {
+		entry.Duration -= entry.Elapsed
+		entry.Updated = entry.Updated.Add(-entry.Elapsed)
+	}
+
+	return !protobuf.Equal(original.ToMessage(), current.ToMessage())
+}
@@ -0,0 +1,320 @@
+package util_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/state"
+	"github.com/SeerUK/tid/pkg/types"
+)
+
+func TestEntryFacadeCreate(t *testing.T) {
+	factory, _, _ := newTestFactory(t)
+	facade := factory.BuildEntryFacade()
+
+	date := time.Date(2017, 3, 1, 0, 0, 0, 0, time.Local)
+
+	entry, err := facade.Create(date, 15*time.Minute, "Afternoon nap")
+	if err != nil {
+		t.Fatalf("unexpected error creating entry: %v", err)
+	}
+
+	if entry.Timesheet != "2017-03-01" || entry.Duration != 15*time.Minute || entry.Note != "Afternoon nap" {
+		t.Errorf("unexpected entry created: %+v", entry)
+	}
+
+	sheet, err := factory.BuildTrackingGateway().FindTimesheet("2017-03-01")
+	if err != nil {
+		t.Fatalf("unexpected error finding timesheet: %v", err)
+	}
+
+	if len(sheet.Entries) != 1 || sheet.Entries[0].Hash != entry.Hash {
+		t.Errorf("expected timesheet to contain the created entry, got: %+v", sheet.Entries)
+	}
+
+	status, _ := factory.BuildSysGateway().FindOrCreateStatus()
+	if status.IsRunning {
+		t.Error("expected creating an entry not to start a timer")
+	}
+}
+
+func TestEntryFacadeUpdate(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildEntryFacade()
+
+	entry, _ := facade.Create(clock.Now(), time.Hour, "Original")
+
+	updated, err := facade.UpdateDuration(entry.ShortHash(), 2*time.Hour)
+	if err != nil || updated.Duration != 2*time.Hour {
+		t.Errorf("expected duration to be updated to 2h, got %s, %v", updated.Duration, err)
+	}
+
+	if _, err := facade.UpdateDuration(entry.Hash, -time.Second); err == nil {
+		t.Error("expected an error updating the duration to less than 0")
+	}
+
+	updated, err = facade.UpdateDurationByOffset(entry.Hash, -30*time.Minute)
+	if err != nil || updated.Duration != 90*time.Minute {
+		t.Errorf("expected duration to be offset to 1h30m, got %s, %v", updated.Duration, err)
+	}
+
+	updated, err = facade.UpdateNote(entry.Hash, "Updated")
+	if err != nil || updated.Note != "Updated" {
+		t.Errorf("expected note to be updated, got %q, %v", updated.Note, err)
+	}
+
+	found, _ := factory.BuildTrackingGateway().FindEntry(entry.Hash)
+	if found.Duration != 90*time.Minute || found.Note != "Updated" {
+		t.Errorf("expected updates to be persisted, got: %+v", found)
+	}
+}
+
+func TestEntryFacadeLog(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildEntryFacade()
+
+	entry, _ := facade.Create(clock.Now(), time.Hour, "Original")
+
+	clock.Advance(time.Minute)
+	facade.UpdateDuration(entry.Hash, 2*time.Hour)
+	facade.UpdateDurationByOffset(entry.Hash, -30*time.Minute)
+	facade.UpdateNote(entry.Hash, "Updated")
+
+	found, log, err := facade.Log(entry.ShortHash())
+	if err != nil {
+		t.Fatalf("unexpected error finding log: %v", err)
+	}
+
+	if found.Hash != entry.Hash || log.Entry != entry.Hash {
+		t.Errorf("expected log for entry %s, got entry %s, log %s", entry.Hash, found.Hash, log.Entry)
+	}
+
+	expected := []types.EntryRevision{
+		{Action: types.RevisionCreate, Duration: time.Hour, Note: "Original"},
+		{Action: types.RevisionUpdateDuration, PreviousDuration: time.Hour, Duration: 2 * time.Hour, PreviousNote: "Original", Note: "Original"},
+		{Action: types.RevisionUpdateDuration, PreviousDuration: 2 * time.Hour, Duration: 90 * time.Minute, PreviousNote: "Original", Note: "Original"},
+		{Action: types.RevisionUpdateNote, PreviousDuration: 90 * time.Minute, Duration: 90 * time.Minute, PreviousNote: "Original", Note: "Updated"},
+	}
+
+	if len(log.Revisions) != len(expected) {
+		t.Fatalf("expected %d revisions, got: %+v", len(expected), log.Revisions)
+	}
+
+	for i, revision := range log.Revisions {
+		if revision.Author == "" {
+			t.Errorf("expected revision %d to have an author", i)
+		}
+
+		revision.Author = ""
+		revision.Created = time.Time{}
+
+		if revision != expected[i] {
+			t.Errorf("expected revision %d to be %+v, got %+v", i, expected[i], revision)
+		}
+	}
+
+	if !log.Revisions[1].Created.Equal(clock.Now()) {
+		t.Errorf("expected revision to be created at %s, got %s", clock.Now(), log.Revisions[1].Created)
+	}
+}
+
+func TestEntryFacadeEdit(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildEntryFacade()
+
+	entry, _ := facade.Create(clock.Now(), time.Hour, "Original")
+	other, _ := facade.Create(clock.Now(), time.Hour, "Other")
+
+	edited := entry
+	edited.Timesheet = "2017-03-02"
+	edited.Created = clock.Now().Add(-time.Hour)
+	edited.Duration = 2 * time.Hour
+	edited.Note = "Edited"
+
+	changed, err := facade.Edit([]types.Entry{entry, other}, []types.Entry{edited, other})
+	if err != nil {
+		t.Fatalf("unexpected error editing entries: %v", err)
+	}
+
+	if len(changed) != 1 || changed[0].Hash != entry.Hash {
+		t.Fatalf("expected only the edited entry to be changed, got: %+v", changed)
+	}
+
+	trGateway := factory.BuildTrackingGateway()
+
+	found, _ := trGateway.FindEntry(entry.Hash)
+	if found.Timesheet != "2017-03-02" || !found.Created.Equal(edited.Created) || found.Duration != 2*time.Hour || found.Note != "Edited" {
+		t.Errorf("expected edits to be persisted, got: %+v", found)
+	}
+
+	from, _ := trGateway.FindTimesheet("2017-03-01")
+	to, _ := trGateway.FindTimesheet("2017-03-02")
+
+	if len(from.Entries) != 1 || from.Entries[0].Hash != other.Hash || len(to.Entries) != 1 || to.Entries[0].Hash != entry.Hash {
+		t.Errorf("expected entry to be moved between timesheets, got: %+v, %+v", from.Entries, to.Entries)
+	}
+
+	edited.Hash = other.Hash
+	if _, err := facade.Edit([]types.Entry{entry}, []types.Entry{edited}); err == nil {
+		t.Error("expected an error editing an entry into a different entry")
+	}
+}
+
+func TestEntryFacadeEditValidatesEveryEntry(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildEntryFacade()
+	trGateway := factory.BuildTrackingGateway()
+
+	first, _ := facade.Create(clock.Now(), time.Hour, "First")
+	second, _ := facade.Create(clock.Now(), time.Hour, "Second")
+
+	editedFirst := first
+	editedFirst.Note = "Edited"
+
+	invalid := second
+	invalid.Duration = -time.Hour
+
+	if _, err := facade.Edit([]types.Entry{first, second}, []types.Entry{editedFirst, invalid}); err == nil {
+		t.Fatal("expected an error editing an entry to a negative duration")
+	}
+
+	if found, _ := trGateway.FindEntry(first.Hash); found.Note != "First" {
+		t.Errorf("expected nothing to be changed when an edited entry is invalid, got note %q", found.Note)
+	}
+
+	// An entry that has been changed since it was read can't be edited, as the edit would undo the
+	// change.
+	clock.Advance(time.Minute)
+	facade.UpdateNote(second.Hash, "Changed")
+
+	if _, err := facade.Edit([]types.Entry{first, second}, []types.Entry{editedFirst, second}); err == nil {
+		t.Fatal("expected an error editing an entry that has been changed since it was read")
+	}
+
+	if found, _ := trGateway.FindEntry(first.Hash); found.Note != "First" {
+		t.Errorf("expected nothing to be changed when an entry has been changed, got note %q", found.Note)
+	}
+
+	// Time tracked by a running timer isn't a change.
+	running, _ := factory.BuildTrackingFacade().Start("Running", 0, 0, types.DayBoundary{})
+	clock.Advance(time.Minute)
+
+	running, _ = trGateway.FindEntry(running.Hash)
+	clock.Advance(time.Minute)
+
+	editedRunning := running
+	editedRunning.Note = "Edited"
+
+	if _, err := facade.Edit([]types.Entry{running}, []types.Entry{editedRunning}); err != nil {
+		t.Errorf("unexpected error editing a running entry: %v", err)
+	}
+}
+
+func TestEntryFacadeFindMatchingDeleteMany(t *testing.T) {
+	factory, _, _ := newTestFactory(t)
+	facade := factory.BuildEntryFacade()
+
+	first, _ := facade.Create(time.Date(2017, 3, 1, 0, 0, 0, 0, time.Local), time.Hour, "JIRA-12 fix")
+	facade.Create(time.Date(2017, 3, 1, 0, 0, 0, 0, time.Local), time.Hour, "Lunch")
+	last, _ := facade.Create(time.Date(2017, 3, 5, 0, 0, 0, 0, time.Local), time.Hour, "JIRA-12 review")
+
+	filter, _ := types.ParseEntryFilter(`note~"JIRA-12"`)
+
+	entries, err := facade.FindMatching(filter)
+	if err != nil {
+		t.Fatalf("unexpected error finding entries: %v", err)
+	}
+
+	if len(entries) != 2 || entries[0].Hash != first.Hash || entries[1].Hash != last.Hash {
+		t.Fatalf("expected matching entries on every timesheet, oldest first, got: %+v", entries)
+	}
+
+	// An empty filter would match every entry.
+	empty, _ := types.ParseEntryFilter(" ")
+
+	if _, err := facade.FindMatching(empty); err == nil {
+		t.Error("expected an error finding entries with an empty filter")
+	}
+
+	// Nothing is deleted if any of the entries are missing.
+	if _, err := facade.DeleteMany([]types.Entry{first, {Hash: "missing"}}); err == nil {
+		t.Error("expected an error deleting a missing entry")
+	}
+
+	if entries, _ := facade.FindMatching(filter); len(entries) != 2 {
+		t.Errorf("expected nothing to be deleted when an entry is missing, got: %+v", entries)
+	}
+
+	// Nor if any of the entries have been changed since they were read.
+	facade.UpdateNote(last.Hash, "JIRA-12 review, again")
+
+	if _, err := facade.DeleteMany(entries); err == nil {
+		t.Error("expected an error deleting an entry that has been changed since it was read")
+	}
+
+	if entries, _ := facade.FindMatching(filter); len(entries) != 2 {
+		t.Errorf("expected nothing to be deleted when an entry has changed, got: %+v", entries)
+	}
+
+	factory.BuildJournalFacade().Undo()
+
+	deleted, err := facade.DeleteMany(entries)
+	if err != nil || len(deleted) != 2 {
+		t.Fatalf("expected 2 entries to be deleted, got %d, %v", len(deleted), err)
+	}
+
+	everything, _ := types.ParseEntryFilter("date>=2017-03-01")
+
+	if entries, _ := facade.FindMatching(everything); len(entries) != 1 || entries[0].Note != "Lunch" {
+		t.Errorf("expected only the unmatched entry to remain, got: %+v", entries)
+	}
+
+	if _, err := factory.BuildJournalFacade().Undo(); err != nil {
+		t.Fatalf("unexpected error undoing: %v", err)
+	}
+
+	if entries, _ := facade.FindMatching(filter); len(entries) != 2 {
+		t.Errorf("expected deleting many entries to be undone at once, got: %+v", entries)
+	}
+}
+
+func TestEntryFacadeDelete(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildEntryFacade()
+
+	entry, _ := factory.BuildTrackingFacade().Start("Running", 0, 0, types.DayBoundary{})
+	other, _ := facade.Create(clock.Now(), time.Hour, "Other")
+
+	deleted, err := facade.Delete(entry.ShortHash())
+	if err != nil {
+		t.Fatalf("unexpected error deleting entry: %v", err)
+	}
+
+	if deleted.Hash != entry.Hash {
+		t.Errorf("expected to delete entry %s, got %s", entry.Hash, deleted.Hash)
+	}
+
+	trGateway := factory.BuildTrackingGateway()
+
+	if _, err := trGateway.FindEntry(entry.Hash); err != state.ErrStoreNilResult {
+		t.Errorf("expected deleted entry not to be found, got: %v", err)
+	}
+
+	if _, err := trGateway.FindEntry(entry.ShortHash()); err != state.ErrStoreNilResult {
+		t.Errorf("expected deleted entry not to be found by short hash, got: %v", err)
+	}
+
+	if log, _ := trGateway.FindOrCreateEntryLog(entry.Hash); len(log.Revisions) != 0 {
+		t.Errorf("expected deleted entry's log to be deleted, got: %+v", log.Revisions)
+	}
+
+	sheet, _ := trGateway.FindTimesheet(entry.Timesheet)
+	if len(sheet.Entries) != 1 || sheet.Entries[0].Hash != other.Hash {
+		t.Errorf("expected only the other entry to be left on the timesheet, got: %+v", sheet.Entries)
+	}
+
+	status, _ := factory.BuildSysGateway().FindOrCreateStatus()
+	if status.IsRunning || status.Entry != "" {
+		t.Errorf("expected deleting the running entry to clear the status, got: %+v", status)
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 
 	"github.com/SeerUK/tid/pkg/state"
+	"github.com/SeerUK/tid/pkg/xtime"
 )
 
 // Factory abstracts the creation of services.
 type Factory interface {
+	// BuildCalendarFacade builds a CalendarFacade instance.
+	BuildCalendarFacade() *CalendarFacade
 	// BuildEntryFacade builds an EntryFacade instance.
 	BuildEntryFacade() *EntryFacade
 	// BuildTimesheetFacade builds an TimesheetFacade instance.
@@ -16,17 +19,33 @@ type Factory interface {
 	BuildTrackingFacade() *TrackingFacade
 	// BuildWorkspaceFacade builds a WorkspaceFacade instance.
 	BuildWorkspaceFacade() *WorkspaceFacade
+	// BuildJournalFacade builds a JournalFacade instance.
+	BuildJournalFacade() *JournalFacade
+	// BuildSearchFacade builds a SearchFacade instance.
+	BuildSearchFacade() *SearchFacade
+	// BuildClock builds a Clock instance, to get the current time from.
+	BuildClock() xtime.Clock
 	// BuildSysGateway builds a SysGateway instance.
 	BuildSysGateway() state.SysGateway
 	// BuildTrackingGateway builds a TimesheetGateway instance.
 	BuildTrackingGateway() state.TrackingGateway
 }
 
+// FactoryOpener opens a writable connection to the database, for commands that only change anything
+// once they've finished waiting on the user. It returns a Factory using the connection, and a
+// function that releases the connection once the Factory is no longer needed.
+type FactoryOpener func() (Factory, func() error, error)
+
 // standardFactory provides a standard, simple, functional implementation of the
 // Factory interface.
 type standardFactory struct {
-	// backend keeps the reference to the storage backend to re-use.
+	// backend keeps the reference to the storage backend to re-use. Changes made through it are
+	// recorded in the journal.
 	backend state.Backend
+	// journal keeps the reference to the Journal to re-use.
+	journal state.Journal
+	// clock keeps the reference to the Clock to re-use.
+	clock xtime.Clock
 	// sysGateway keeps the reference to a SysGateway instance to re-use.
 	sysGateway state.SysGateway
 	// trackingGateway keeps the reference to a TimesheetGateway to re-use.
@@ -34,26 +53,46 @@ type standardFactory struct {
 }
 
 // NewStandardFactory creates a new Factory instance.
-func NewStandardFactory(backend state.Backend) Factory {
+func NewStandardFactory(backend state.Backend, clock xtime.Clock) Factory {
+	journal := state.NewJournalBackend(backend, clock)
+
 	return &standardFactory{
-		backend: backend,
+		backend: journal,
+		journal: journal,
+		clock:   clock,
 	}
 }
 
+func (f *standardFactory) BuildCalendarFacade() *CalendarFacade {
+	return NewCalendarFacade(f.BuildSysGateway(), f.journal)
+}
+
 func (f *standardFactory) BuildEntryFacade() *EntryFacade {
-	return NewEntryFacade(f.BuildSysGateway(), f.BuildTrackingGateway())
+	return NewEntryFacade(f.BuildSysGateway(), f.BuildTrackingGateway(), f.clock, f.journal)
 }
 
 func (f *standardFactory) BuildTimesheetFacade() *TimesheetFacade {
-	return NewTimesheetFacade(f.BuildTrackingGateway(), f.BuildEntryFacade())
+	return NewTimesheetFacade(f.BuildTrackingGateway(), f.BuildEntryFacade(), f.journal)
 }
 
 func (f *standardFactory) BuildTrackingFacade() *TrackingFacade {
-	return NewTrackingFacade(f.BuildSysGateway(), f.BuildTrackingGateway())
+	return NewTrackingFacade(f.BuildSysGateway(), f.BuildTrackingGateway(), f.clock, f.journal)
 }
 
 func (f *standardFactory) BuildWorkspaceFacade() *WorkspaceFacade {
-	return NewWorkspaceFacade(f.backend, f.BuildSysGateway())
+	return NewWorkspaceFacade(f.backend, f.BuildSysGateway(), f.journal)
+}
+
+func (f *standardFactory) BuildJournalFacade() *JournalFacade {
+	return NewJournalFacade(f.backend, f.BuildSysGateway(), f.clock)
+}
+
+func (f *standardFactory) BuildSearchFacade() *SearchFacade {
+	return NewSearchFacade(f.backend, f.BuildSysGateway(), f.clock)
+}
+
+func (f *standardFactory) BuildClock() xtime.Clock {
+	return f.clock
 }
 
 func (f *standardFactory) BuildSysGateway() state.SysGateway {
@@ -75,7 +114,7 @@ func (f *standardFactory) BuildTrackingGateway() state.TrackingGateway {
 		status.Workspace,
 	))
 
-	return state.NewStoreTrackingGateway(tsStore, sysGateway)
+	return state.NewStoreTrackingGateway(tsStore, sysGateway, f.clock)
 }
 
 // getStore gets the application data store.
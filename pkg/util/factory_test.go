@@ -0,0 +1,26 @@
+package util_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/state"
+	"github.com/SeerUK/tid/pkg/state/memory"
+	"github.com/SeerUK/tid/pkg/state/migrate"
+	_ "github.com/SeerUK/tid/pkg/state/migrate/versions"
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+// newTestFactory creates a Factory backed by a fresh, migrated, in-memory Backend, and a FakeClock
+// stopped at midday on 2017-03-01.
+func newTestFactory(t *testing.T) (util.Factory, state.Backend, *xtime.FakeClock) {
+	backend := memory.NewMemoryBackend()
+	clock := xtime.NewFakeClock(time.Date(2017, 3, 1, 12, 0, 0, 0, time.Local))
+
+	if err := migrate.Backend(backend); err != nil {
+		t.Fatalf("unexpected error migrating: %v", err)
+	}
+
+	return util.NewStandardFactory(backend, clock), backend, clock
+}
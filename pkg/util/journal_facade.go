@@ -0,0 +1,341 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/SeerUK/tid/pkg/state"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+var (
+	// ErrNothingToUndo is an error reported when attempting to undo an operation, but every
+	// operation in the journal has already been undone.
+	ErrNothingToUndo = errors.New("journal: There is nothing to undo")
+	// ErrNothingToRedo is an error reported when attempting to redo an operation, but no operation
+	// has been undone since the last change.
+	ErrNothingToRedo = errors.New("journal: There is nothing to redo")
+)
+
+// JournalFacade provides a simpler interface for undoing and redoing operations recorded in the
+// journal.
+type JournalFacade struct {
+	// backend is a lower-level backend storage interface.
+	backend state.Backend
+	// sysGateway is a SysGateway used for accessing system storage.
+	sysGateway state.SysGateway
+	// clock is a Clock used to get the current time.
+	clock xtime.Clock
+}
+
+// NewJournalFacade creates a new JournalFacade instance.
+func NewJournalFacade(backend state.Backend, sysGateway state.SysGateway, clock xtime.Clock) *JournalFacade {
+	return &JournalFacade{
+		backend:    backend,
+		sysGateway: sysGateway,
+		clock:      clock,
+	}
+}
+
+// History returns up to the given number of the most recent operations in the journal, most recent
+// first, and how many of them can be redone, i.e. have been undone.
+func (f *JournalFacade) History(limit int) ([]types.JournalOperation, int, error) {
+	var operations []types.JournalOperation
+
+	journal, err := f.sysGateway.FindOrCreateJournal()
+	if err != nil {
+		return operations, 0, err
+	}
+
+	for i := len(journal.Operations) - 1; i >= 0 && len(operations) < limit; i-- {
+		operation, err := f.sysGateway.FindJournalOperation(journal.Operations[i])
+		if err != nil {
+			return operations, 0, err
+		}
+
+		operations = append(operations, operation)
+	}
+
+	undone := len(journal.Operations) - journal.Position
+	if undone > len(operations) {
+		undone = len(operations)
+	}
+
+	return operations, undone, nil
+}
+
+// Undo reverts the most recent operation in the journal that hasn't been undone, restoring
+// everything it changed to how it was before. Nothing is reverted if anything the operation changed
+// has been changed since, and every change is reverted together, so nothing is reverted if any of
+// it fails. Entry logs are only ever added to, so an "undo" revision is added to the log of each
+// entry the operation changed, rather than the log being restored, unless the entry itself is being
+// restored or removed.
+func (f *JournalFacade) Undo() (types.JournalOperation, error) {
+	var operation types.JournalOperation
+
+	err := f.backend.Update(func(backend state.Backend) error {
+		sysGateway := state.NewStoreSysGateway(state.NewBackendStore(backend, state.BackendBucketSys))
+
+		journal, err := sysGateway.FindOrCreateJournal()
+		if err != nil {
+			return err
+		}
+
+		if journal.Position == 0 {
+			return ErrNothingToUndo
+		}
+
+		operation, err = sysGateway.FindJournalOperation(journal.Operations[journal.Position-1])
+		if err != nil {
+			return err
+		}
+
+		for _, change := range operation.Changes {
+			if !isChecked(change) {
+				continue
+			}
+
+			if !matches(backend, change.Bucket, change.Key, change.After, change.Deleted) {
+				return fmt.Errorf("journal: Can't undo '%s', something it changed has changed since", operation.Description)
+			}
+		}
+
+		err = f.revise(backend, sysGateway, operation, types.RevisionUndo, func() error {
+			restored := restoredLogs(operation)
+
+			// Changes are reverted in the opposite order to how they were made, so that buckets
+			// exist before keys are restored into them.
+			for i := len(operation.Changes) - 1; i >= 0; i-- {
+				change := operation.Changes[i]
+
+				if isLog(change) && !restored[change.Bucket+"\x00"+change.Key] {
+					continue
+				}
+
+				if err := apply(backend, change, change.Before, change.Created); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		journal.Position--
+
+		return sysGateway.PersistJournal(journal)
+	})
+
+	return operation, err
+}
+
+// Redo re-applies the most recently undone operation in the journal. Nothing is changed if anything
+// the operation changed has been changed since it was undone, and every change is re-applied
+// together, so nothing is changed if any of it fails. As with Undo, a "redo" revision is added to
+// the log of each entry the operation changed, rather than the log being re-applied.
+func (f *JournalFacade) Redo() (types.JournalOperation, error) {
+	var operation types.JournalOperation
+
+	err := f.backend.Update(func(backend state.Backend) error {
+		sysGateway := state.NewStoreSysGateway(state.NewBackendStore(backend, state.BackendBucketSys))
+
+		journal, err := sysGateway.FindOrCreateJournal()
+		if err != nil {
+			return err
+		}
+
+		if journal.Position >= len(journal.Operations) {
+			return ErrNothingToRedo
+		}
+
+		operation, err = sysGateway.FindJournalOperation(journal.Operations[journal.Position])
+		if err != nil {
+			return err
+		}
+
+		for _, change := range operation.Changes {
+			if !isChecked(change) {
+				continue
+			}
+
+			if !matches(backend, change.Bucket, change.Key, change.Before, change.Created) {
+				return fmt.Errorf("journal: Can't redo '%s', something it changed has changed since", operation.Description)
+			}
+		}
+
+		err = f.revise(backend, sysGateway, operation, types.RevisionRedo, func() error {
+			restored := restoredLogs(operation)
+
+			for _, change := range operation.Changes {
+				if isLog(change) && !restored[change.Bucket+"\x00"+change.Key] {
+					continue
+				}
+
+				if err := apply(backend, change, change.After, change.Deleted); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		journal.Position++
+
+		return sysGateway.PersistJournal(journal)
+	})
+
+	return operation, err
+}
+
+// revise runs the given function, which undoes or redoes the given operation in the given backend,
+// then appends a revision with the given action to the log of each entry the operation changed that
+// still exists, recording how the function changed it.
+func (f *JournalFacade) revise(backend state.Backend, sysGateway state.SysGateway, operation types.JournalOperation, action string, fn func() error) error {
+	type revised struct {
+		trGateway state.TrackingGateway
+		hash      string
+		previous  types.Entry
+		existed   bool
+	}
+
+	var entries []revised
+
+	for _, change := range operation.Changes {
+		hash, ok := entryHash(change)
+		if !ok {
+			continue
+		}
+
+		store := state.NewBackendStore(backend, change.Bucket)
+		trGateway := state.NewStoreTrackingGateway(store, sysGateway, f.clock)
+
+		previous, err := trGateway.FindEntry(hash)
+		if err != nil && err != state.ErrStoreNilResult && err != state.ErrNilBucket {
+			return err
+		}
+
+		entries = append(entries, revised{trGateway, hash, previous, err == nil})
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		current, err := entry.trGateway.FindEntry(entry.hash)
+		if err == state.ErrStoreNilResult || err == state.ErrNilBucket {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		previous := entry.previous
+		if !entry.existed {
+			previous = current
+		}
+
+		revision := types.NewEntryRevision(action, previous, current, currentAuthor(), f.clock.Now())
+
+		if err := entry.trGateway.AppendEntryRevision(entry.hash, revision); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// entryHash returns the hash of the entry changed by the given change, if it changed an entry.
+// Entries are stored under their full hash, and referenced by their short hash.
+func entryHash(change types.JournalChange) (string, bool) {
+	var hash string
+	if _, err := fmt.Sscanf(change.Key, state.KeyEntryFmt, &hash); err != nil || len(hash) != 40 {
+		return "", false
+	}
+
+	return hash, true
+}
+
+// isLog returns true if the given change changed an entry's log.
+func isLog(change types.JournalChange) bool {
+	return strings.HasPrefix(change.Key, fmt.Sprintf(state.KeyEntryLogFmt, ""))
+}
+
+// isChecked returns true if the given change needs checking before it's undone or redone, to make
+// sure that what it changed hasn't been changed since. Entry logs are only ever added to, so they
+// may well have been.
+func isChecked(change types.JournalChange) bool {
+	return !change.IsBucketChange() && !isLog(change)
+}
+
+// restoredLogs returns the buckets and keys of the entry logs that are restored or removed when the
+// given operation is undone or redone, i.e. the logs of the entries it created or deleted. Other
+// entry logs are left alone.
+func restoredLogs(operation types.JournalOperation) map[string]bool {
+	restored := make(map[string]bool)
+
+	for _, change := range operation.Changes {
+		hash, ok := entryHash(change)
+		if ok && (change.Created || change.Deleted) {
+			restored[change.Bucket+"\x00"+fmt.Sprintf(state.KeyEntryLogFmt, hash)] = true
+		}
+	}
+
+	return restored
+}
+
+// endOperation stops recording the current operation in the given journal. It's intended to be
+// deferred, and reports an error saving the operation through the given error, unless that already
+// holds an error.
+func endOperation(journal state.Journal, err *error) {
+	if endErr := journal.End(); *err == nil {
+		*err = endErr
+	}
+}
+
+// matches returns true if the given key currently has the given value in the given backend, or
+// doesn't exist if absent is true.
+func matches(backend state.Backend, bucket string, key string, value []byte, absent bool) bool {
+	current, err := backend.Read(bucket, key)
+	if err != nil {
+		return absent
+	}
+
+	return !absent && bytes.Equal(current, value)
+}
+
+// apply sets the bucket or key changed by the given change in the given backend to the given value,
+// or removes it if absent is true.
+func apply(backend state.Backend, change types.JournalChange, value []byte, absent bool) error {
+	exists := backend.HasBucket(change.Bucket)
+
+	switch {
+	case change.IsBucketChange() && absent && exists:
+		return backend.DeleteBucket(change.Bucket)
+	case change.IsBucketChange() && !absent:
+		return backend.CreateBucketIfNotExists(change.Bucket)
+	case change.IsBucketChange():
+		return nil
+	case absent && exists:
+		return backend.Delete(change.Bucket, change.Key)
+	case absent:
+		return nil
+	}
+
+	if err := backend.CreateBucketIfNotExists(change.Bucket); err != nil {
+		return err
+	}
+
+	return backend.Write(change.Bucket, change.Key, value)
+}
@@ -0,0 +1,287 @@
+package util_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/state"
+	"github.com/SeerUK/tid/pkg/state/memory"
+	"github.com/SeerUK/tid/pkg/state/migrate"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/util"
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+func TestJournalFacadeUndoRedo(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	journal := factory.BuildJournalFacade()
+	trGateway := factory.BuildTrackingGateway()
+
+	if _, err := journal.Undo(); err != util.ErrNothingToUndo {
+		t.Errorf("expected nothing to undo, got %v", err)
+	}
+
+	boundary := types.DayBoundary{}
+
+	entry, _ := factory.BuildTrackingFacade().Start("Mistake", 0, 0, boundary)
+	clock.Advance(time.Hour)
+	factory.BuildTrackingFacade().Stop(boundary)
+	factory.BuildEntryFacade().Delete(entry.Hash)
+
+	undone, err := journal.Undo()
+	if err != nil {
+		t.Fatalf("unexpected error undoing: %v", err)
+	}
+
+	if undone.Description != "Delete entry" || len(undone.Entries) != 1 || undone.Entries[0].Hash != entry.Hash {
+		t.Errorf("expected to undo deleting %s, got %+v", entry.ShortHash(), undone)
+	}
+
+	restored, err := trGateway.FindEntry(entry.ShortHash())
+	if err != nil || restored.Duration != time.Hour {
+		t.Errorf("expected the deleted entry to be restored, got %+v, %v", restored, err)
+	}
+
+	if log, _ := trGateway.FindOrCreateEntryLog(entry.Hash); len(log.Revisions) != 3 || log.Revisions[2].Action != types.RevisionUndo {
+		t.Errorf("expected the deleted entry's log to be restored, and the undo added, got %+v", log.Revisions)
+	}
+
+	sheet, _ := trGateway.FindTimesheet(entry.Timesheet)
+	if len(sheet.Entries) != 1 {
+		t.Errorf("expected the entry to be back on it's timesheet, got %v", sheet.Entries)
+	}
+
+	if _, err := journal.Redo(); err != nil {
+		t.Fatalf("unexpected error redoing: %v", err)
+	}
+
+	if _, err := trGateway.FindEntry(entry.Hash); err != state.ErrStoreNilResult {
+		t.Errorf("expected the entry to be deleted again, got %v", err)
+	}
+
+	if _, err := journal.Redo(); err != util.ErrNothingToRedo {
+		t.Errorf("expected nothing to redo, got %v", err)
+	}
+
+	operations, redoable, _ := journal.History(10)
+	if len(operations) != 3 || redoable != 0 || operations[0].Description != "Delete entry" {
+		t.Errorf("expected 3 operations, most recent first, got %d (%d undone)", len(operations), redoable)
+	}
+}
+
+func TestJournalFacadeUndoConflict(t *testing.T) {
+	factory, _, _ := newTestFactory(t)
+	journal := factory.BuildJournalFacade()
+	entryFacade := factory.BuildEntryFacade()
+	trGateway := factory.BuildTrackingGateway()
+
+	entry, _ := entryFacade.Create(time.Now(), time.Hour, "Original")
+	entryFacade.UpdateNote(entry.Hash, "Renamed")
+
+	journal.Undo()
+
+	// A new change discards the undone rename.
+	entryFacade.UpdateNote(entry.Hash, "Changed")
+
+	if _, err := journal.Redo(); err != util.ErrNothingToRedo {
+		t.Errorf("expected nothing to redo after a new change, got %v", err)
+	}
+
+	// Changes made outside of an operation aren't recorded, so can't be undone, and stop anything
+	// they conflict with being undone.
+	found, _ := trGateway.FindEntry(entry.Hash)
+	found.Note = "Unrecorded"
+	trGateway.PersistEntry(found)
+
+	if _, err := journal.Undo(); err == nil {
+		t.Fatal("expected an error undoing a change that has been changed since")
+	}
+
+	found, _ = trGateway.FindEntry(entry.Hash)
+	if found.Note != "Unrecorded" {
+		t.Errorf("expected nothing to be undone, got %q", found.Note)
+	}
+}
+
+func TestJournalFacadeUndoEntryLog(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	journal := factory.BuildJournalFacade()
+	entryFacade := factory.BuildEntryFacade()
+	trGateway := factory.BuildTrackingGateway()
+
+	entry, _ := entryFacade.Create(clock.Now(), time.Hour, "Original")
+	entryFacade.UpdateNote(entry.Hash, "Renamed")
+	entryFacade.UpdateDuration(entry.Hash, 2*time.Hour)
+
+	// Undoing the duration change adds to the log, which mustn't stop the rename being undone.
+	for i := 0; i < 2; i++ {
+		if _, err := journal.Undo(); err != nil {
+			t.Fatalf("unexpected error undoing: %v", err)
+		}
+	}
+
+	if _, err := journal.Redo(); err != nil {
+		t.Fatalf("unexpected error redoing: %v", err)
+	}
+
+	log, _ := trGateway.FindOrCreateEntryLog(entry.Hash)
+
+	expected := []types.EntryRevision{
+		{Action: types.RevisionCreate, Note: "Original", Duration: time.Hour},
+		{Action: types.RevisionUpdateNote, PreviousNote: "Original", Note: "Renamed", PreviousDuration: time.Hour, Duration: time.Hour},
+		{Action: types.RevisionUpdateDuration, PreviousNote: "Renamed", Note: "Renamed", PreviousDuration: time.Hour, Duration: 2 * time.Hour},
+		{Action: types.RevisionUndo, PreviousNote: "Renamed", Note: "Renamed", PreviousDuration: 2 * time.Hour, Duration: time.Hour},
+		{Action: types.RevisionUndo, PreviousNote: "Renamed", Note: "Original", PreviousDuration: time.Hour, Duration: time.Hour},
+		{Action: types.RevisionRedo, PreviousNote: "Original", Note: "Renamed", PreviousDuration: time.Hour, Duration: time.Hour},
+	}
+
+	if len(log.Revisions) != len(expected) {
+		t.Fatalf("expected %d revisions, got %+v", len(expected), log.Revisions)
+	}
+
+	for i, revision := range log.Revisions {
+		revision.Created = time.Time{}
+		revision.Author = ""
+
+		if revision != expected[i] {
+			t.Errorf("expected revision %d to be %+v, got %+v", i, expected[i], revision)
+		}
+	}
+}
+
+func TestJournalFacadeUndoWorkspaceDelete(t *testing.T) {
+	factory, backend, _ := newTestFactory(t)
+	journal := factory.BuildJournalFacade()
+	workspaceFacade := factory.BuildWorkspaceFacade()
+
+	workspaceFacade.Create("freelance")
+	workspaceFacade.Switch("freelance")
+
+	entry, _ := factory.BuildEntryFacade().Create(time.Now(), time.Hour, "Client work")
+
+	workspaceFacade.Switch(types.TrackingStatusDefaultWorkspace)
+	workspaceFacade.Delete("freelance")
+
+	if _, err := journal.Undo(); err != nil {
+		t.Fatalf("unexpected error undoing: %v", err)
+	}
+
+	if !backend.HasBucket(fmt.Sprintf(state.BackendBucketWorkspaceFmt, "freelance")) {
+		t.Fatal("expected the workspace bucket to be restored")
+	}
+
+	workspaceFacade.Switch("freelance")
+
+	if _, err := factory.BuildTrackingGateway().FindEntry(entry.Hash); err != nil {
+		t.Errorf("expected the workspace's entries to be restored, got %v", err)
+	}
+}
+
+// countingBackend is a state.Backend that counts writes to keys with a given prefix, and can be
+// made to fail writing them.
+type countingBackend struct {
+	state.Backend
+
+	prefix string
+	writes int
+	fail   bool
+}
+
+func (b *countingBackend) Write(bucket string, key string, val []byte) error {
+	if strings.HasPrefix(key, b.prefix) {
+		b.writes++
+
+		if b.fail {
+			return errors.New("test: Write failed")
+		}
+	}
+
+	return b.Backend.Write(bucket, key, val)
+}
+
+func (b *countingBackend) Update(fn func(backend state.Backend) error) error {
+	return b.Backend.Update(func(backend state.Backend) error {
+		tx := &countingBackend{Backend: backend, prefix: b.prefix, fail: b.fail}
+		err := fn(tx)
+
+		b.writes += tx.writes
+
+		return err
+	})
+}
+
+func TestJournalFacadeOperationSavedOnce(t *testing.T) {
+	backend := &countingBackend{Backend: memory.NewMemoryBackend(), prefix: "journal:"}
+	clock := xtime.NewFakeClock(time.Date(2017, 3, 1, 12, 0, 0, 0, time.Local))
+
+	if err := migrate.Backend(backend); err != nil {
+		t.Fatalf("unexpected error migrating: %v", err)
+	}
+
+	factory := util.NewStandardFactory(backend, clock)
+
+	entries := make([]types.Entry, 0, 5)
+	for i := 0; i < 5; i++ {
+		entry, _ := factory.BuildEntryFacade().Create(clock.Now(), time.Hour, "Entry")
+		entries = append(entries, entry)
+	}
+
+	backend.writes = 0
+
+	if _, err := factory.BuildEntryFacade().DeleteMany(entries); err != nil {
+		t.Fatalf("unexpected error deleting: %v", err)
+	}
+
+	if backend.writes != 1 {
+		t.Errorf("expected the operation to be saved once, got %d writes", backend.writes)
+	}
+}
+
+func TestJournalFacadeUndoFailure(t *testing.T) {
+	backend := &countingBackend{Backend: memory.NewMemoryBackend(), prefix: "sheet:"}
+	clock := xtime.NewFakeClock(time.Date(2017, 3, 1, 12, 0, 0, 0, time.Local))
+
+	if err := migrate.Backend(backend); err != nil {
+		t.Fatalf("unexpected error migrating: %v", err)
+	}
+
+	factory := util.NewStandardFactory(backend, clock)
+	trGateway := factory.BuildTrackingGateway()
+
+	first, _ := factory.BuildEntryFacade().Create(clock.Now(), time.Hour, "First")
+	second, _ := factory.BuildEntryFacade().Create(clock.Now(), time.Hour, "Second")
+
+	if _, err := factory.BuildEntryFacade().DeleteMany([]types.Entry{first, second}); err != nil {
+		t.Fatalf("unexpected error deleting: %v", err)
+	}
+
+	// Changes are undone in reverse, so restoring the timesheet fails after the deleted entries
+	// have already been restored.
+	backend.fail = true
+
+	if _, err := factory.BuildJournalFacade().Undo(); err == nil {
+		t.Fatal("expected an error undoing")
+	}
+
+	backend.fail = false
+
+	for _, entry := range []types.Entry{first, second} {
+		if _, err := trGateway.FindEntry(entry.Hash); err != state.ErrStoreNilResult {
+			t.Errorf("expected entry %s not to be restored, got %v", entry.ShortHash(), err)
+		}
+	}
+
+	// Nothing was undone, so it can still be undone once whatever failed is fixed.
+	if _, err := factory.BuildJournalFacade().Undo(); err != nil {
+		t.Fatalf("unexpected error undoing: %v", err)
+	}
+
+	for _, entry := range []types.Entry{first, second} {
+		if _, err := trGateway.FindEntry(entry.Hash); err != nil {
+			t.Errorf("expected entry %s to be restored, got %v", entry.ShortHash(), err)
+		}
+	}
+}
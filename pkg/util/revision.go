@@ -0,0 +1,35 @@
+package util
+
+import (
+	"os"
+	"os/user"
+
+	"github.com/SeerUK/tid/pkg/state"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+// UnknownAuthor is the author recorded on revisions when the current user can't be found.
+const UnknownAuthor = "unknown"
+
+// recordRevision appends a revision to the log of the given entry, recording that the given action
+// changed it from previous to current, now, as the current user.
+func recordRevision(trGateway state.TrackingGateway, clock xtime.Clock, action string, previous types.Entry, current types.Entry) error {
+	revision := types.NewEntryRevision(action, previous, current, currentAuthor(), clock.Now())
+
+	return trGateway.AppendEntryRevision(current.Hash, revision)
+}
+
+// currentAuthor returns the name of the user running tid, falling back to the USER environment
+// variable if it can't be looked up.
+func currentAuthor() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+
+	return UnknownAuthor
+}
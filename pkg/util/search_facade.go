@@ -0,0 +1,84 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/SeerUK/tid/pkg/state"
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
+)
+
+// SearchFacade provides a simpler interface for searching for entries, in one or every workspace.
+type SearchFacade struct {
+	// backend is a lower-level backend storage interface.
+	backend state.Backend
+	// sysGateway is a SysGateway used for accessing system storage.
+	sysGateway state.SysGateway
+	// clock is a Clock used to get the current time.
+	clock xtime.Clock
+}
+
+// NewSearchFacade creates a new SearchFacade instance.
+func NewSearchFacade(backend state.Backend, sysGateway state.SysGateway, clock xtime.Clock) *SearchFacade {
+	return &SearchFacade{
+		backend:    backend,
+		sysGateway: sysGateway,
+		clock:      clock,
+	}
+}
+
+// Search finds the entries with notes matching the given query on every timesheet in the current
+// workspace, or in every workspace if all is true. Results are ordered by the date of their
+// timesheet, oldest first.
+func (f *SearchFacade) Search(query types.SearchQuery, all bool) ([]types.SearchResult, error) {
+	var results []types.SearchResult
+
+	status, err := f.sysGateway.FindOrCreateStatus()
+	if err != nil {
+		return results, err
+	}
+
+	workspaces := []string{status.Workspace}
+
+	if all {
+		index, err := f.sysGateway.FindWorkspaceIndex()
+		if err != nil {
+			return results, err
+		}
+
+		workspaces = index.Workspaces
+	}
+
+	for _, workspace := range workspaces {
+		bucket := fmt.Sprintf(state.BackendBucketWorkspaceFmt, workspace)
+		if !f.backend.HasBucket(bucket) {
+			continue
+		}
+
+		trGateway := state.NewStoreTrackingGateway(state.NewBackendStore(f.backend, bucket), f.sysGateway, f.clock)
+
+		sheets, err := trGateway.FindTimesheets()
+		if err != nil {
+			return results, err
+		}
+
+		for _, sheet := range sheets {
+			for _, entry := range sheet.Entries {
+				if group, ok := query.Match(entry.Note); ok {
+					results = append(results, types.SearchResult{
+						Workspace: workspace,
+						Entry:     entry,
+						Group:     group,
+					})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Entry.Timesheet < results[j].Entry.Timesheet
+	})
+
+	return results, nil
+}
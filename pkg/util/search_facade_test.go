@@ -0,0 +1,42 @@
+package util_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/types"
+)
+
+func TestSearchFacadeSearch(t *testing.T) {
+	factory, _, _ := newTestFactory(t)
+
+	factory.BuildEntryFacade().Create(time.Date(2017, 3, 2, 0, 0, 0, 0, time.Local), time.Hour, "Billing migration")
+	factory.BuildEntryFacade().Create(time.Date(2017, 3, 1, 0, 0, 0, 0, time.Local), time.Hour, "Lunch")
+
+	workspaces := factory.BuildWorkspaceFacade()
+	workspaces.Create("other")
+	workspaces.Switch("other")
+
+	factory.BuildEntryFacade().Create(time.Date(2017, 3, 1, 0, 0, 0, 0, time.Local), time.Hour, "More billing migration")
+
+	query, _ := types.NewSearchQuery("billing migration", false, true)
+	facade := factory.BuildSearchFacade()
+
+	results, err := facade.Search(query, false)
+	if err != nil {
+		t.Fatalf("unexpected error searching: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Workspace != "other" || results[0].Entry.Note != "More billing migration" {
+		t.Errorf("expected only the current workspace to be searched, got: %+v", results)
+	}
+
+	results, err = facade.Search(query, true)
+	if err != nil {
+		t.Fatalf("unexpected error searching every workspace: %v", err)
+	}
+
+	if len(results) != 2 || results[0].Workspace != "other" || results[1].Workspace != "default" {
+		t.Errorf("expected results from every workspace, oldest first, got: %+v", results)
+	}
+}
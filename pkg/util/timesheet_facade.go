@@ -1,6 +1,7 @@
 package util
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/SeerUK/tid/pkg/state"
@@ -14,18 +15,24 @@ type TimesheetFacade struct {
 	entryFacade *EntryFacade
 	// trGateway is a TimesheetGateway used for accessing timesheet storage.
 	trGateway state.TrackingGateway
+	// journal is a Journal used to record changes, so that they can be undone.
+	journal state.Journal
 }
 
 // NewTimesheetFacade creates a new TimesheetFacade instance.
-func NewTimesheetFacade(trGateway state.TrackingGateway, entryFacade *EntryFacade) *TimesheetFacade {
+func NewTimesheetFacade(trGateway state.TrackingGateway, entryFacade *EntryFacade, journal state.Journal) *TimesheetFacade {
 	return &TimesheetFacade{
 		entryFacade: entryFacade,
 		trGateway:   trGateway,
+		journal:     journal,
 	}
 }
 
 // Delete attempts to delete a timesheet at the given date.
-func (f *TimesheetFacade) Delete(date time.Time) (types.Timesheet, error) {
+func (f *TimesheetFacade) Delete(date time.Time) (_ types.Timesheet, err error) {
+	f.journal.Begin(fmt.Sprintf("Delete timesheet %s", date.Format(xtime.DateFmt)))
+	defer endOperation(f.journal, &err)
+
 	sheet, err := f.trGateway.FindTimesheet(date.Format(xtime.DateFmt))
 	if err != nil {
 		return sheet, err
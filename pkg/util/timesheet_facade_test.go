@@ -0,0 +1,53 @@
+package util_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/state"
+)
+
+func TestTimesheetFacadeDelete(t *testing.T) {
+	factory, _, _ := newTestFactory(t)
+
+	date := time.Date(2017, 3, 1, 0, 0, 0, 0, time.Local)
+	other := date.AddDate(0, 0, 1)
+
+	entryFacade := factory.BuildEntryFacade()
+	first, _ := entryFacade.Create(date, time.Hour, "First")
+	second, _ := entryFacade.Create(date, time.Hour, "Second")
+	kept, _ := entryFacade.Create(other, time.Hour, "Kept")
+
+	sheet, err := factory.BuildTimesheetFacade().Delete(date)
+	if err != nil {
+		t.Fatalf("unexpected error deleting timesheet: %v", err)
+	}
+
+	if sheet.Key != "2017-03-01" {
+		t.Errorf("expected to delete timesheet 2017-03-01, got %s", sheet.Key)
+	}
+
+	trGateway := factory.BuildTrackingGateway()
+
+	if _, err := trGateway.FindTimesheet("2017-03-01"); err != state.ErrStoreNilResult {
+		t.Errorf("expected deleted timesheet not to be found, got: %v", err)
+	}
+
+	for _, entry := range []string{first.Hash, second.Hash} {
+		if _, err := trGateway.FindEntry(entry); err != state.ErrStoreNilResult {
+			t.Errorf("expected entry %s on the deleted timesheet to be deleted, got: %v", entry, err)
+		}
+
+		if log, _ := trGateway.FindOrCreateEntryLog(entry); len(log.Revisions) != 0 {
+			t.Errorf("expected the log of entry %s to be deleted, got: %+v", entry, log.Revisions)
+		}
+	}
+
+	if _, err := trGateway.FindEntry(kept.Hash); err != nil {
+		t.Errorf("expected entry on another timesheet to be kept, got: %v", err)
+	}
+
+	if _, err := factory.BuildTimesheetFacade().Delete(date); err != state.ErrStoreNilResult {
+		t.Errorf("expected an error deleting a missing timesheet, got: %v", err)
+	}
+}
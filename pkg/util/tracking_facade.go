@@ -2,10 +2,16 @@ package util
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/SeerUK/tid/pkg/errhandling"
 	"github.com/SeerUK/tid/pkg/state"
 	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/xtime"
 )
 
 var (
@@ -15,26 +21,43 @@ var (
 	// ErrTimerRunning is an error reported when an action is attempted that requires that no timer
 	// is running, but there is one running.
 	ErrTimerRunning = errors.New("tracking: Stop your existing timer before starting a new one")
+	// ErrNoMatchingEntry is an error reported when looking for an entry by it's note, but no entry
+	// has a matching note.
+	ErrNoMatchingEntry = errors.New("tracking: No entry has a matching note")
 )
 
+// BreakNote is the note given to break entries recorded after a timeboxed entry has expired.
+const BreakNote = "Break"
+
 // TrackingFacade provides a simpler interface for common general tracking-related tasks.
 type TrackingFacade struct {
 	// sysGateway is a SysGateway used for accessing system storage.
 	sysGateway state.SysGateway
 	// trGateway is a TrackingGateway used for accessing tracking storage.
 	trGateway state.TrackingGateway
+	// clock is a Clock used to get the current time.
+	clock xtime.Clock
+	// journal is a Journal used to record changes, so that they can be undone.
+	journal state.Journal
 }
 
 // NewTrackingFacade creates a new TrackingFacade instance.
-func NewTrackingFacade(sysGateway state.SysGateway, trGateway state.TrackingGateway) *TrackingFacade {
+func NewTrackingFacade(sysGateway state.SysGateway, trGateway state.TrackingGateway, clock xtime.Clock, journal state.Journal) *TrackingFacade {
 	return &TrackingFacade{
 		sysGateway: sysGateway,
 		trGateway:  trGateway,
+		clock:      clock,
+		journal:    journal,
 	}
 }
 
-// Start a new entry, with the given details.
-func (f *TrackingFacade) Start(note string) (types.Entry, error) {
+// Start a new entry, with the given details, on the timesheet for the current tracking day. If a
+// planned duration is given, the entry is timeboxed, and will be stopped once it has been tracked
+// for that long.
+func (f *TrackingFacade) Start(note string, planned time.Duration, plannedBreak time.Duration, boundary types.DayBoundary) (_ types.Entry, err error) {
+	f.journal.Begin("Start timer")
+	defer endOperation(f.journal, &err)
+
 	var entry types.Entry
 
 	status, err := f.sysGateway.FindOrCreateStatus()
@@ -46,14 +69,19 @@ func (f *TrackingFacade) Start(note string) (types.Entry, error) {
 		return entry, ErrTimerRunning
 	}
 
-	sheet, err := f.trGateway.FindOrCreateTodaysTimesheet()
+	now := f.clock.Now()
+
+	sheet, err := f.trGateway.FindOrCreateTimesheet(boundary.Key(now))
 	if err != nil {
 		return entry, err
 	}
 
-	entry = types.NewEntry()
+	entry = types.NewEntry(now)
 	entry.Note = note
 	entry.Timesheet = sheet.Key
+	entry.Planned = planned
+	entry.PlannedBreak = plannedBreak
+	entry.MarkStarted(now)
 
 	sheet.AppendEntry(entry)
 
@@ -63,6 +91,7 @@ func (f *TrackingFacade) Start(note string) (types.Entry, error) {
 	errs.Add(f.sysGateway.PersistStatus(status))
 	errs.Add(f.trGateway.PersistEntry(entry))
 	errs.Add(f.trGateway.PersistTimesheet(sheet))
+	errs.Add(recordRevision(f.trGateway, f.clock, types.RevisionStart, types.Entry{}, entry))
 
 	if err = errs.Errors(); err != nil {
 		return entry, err
@@ -71,8 +100,13 @@ func (f *TrackingFacade) Start(note string) (types.Entry, error) {
 	return entry, nil
 }
 
-// Stop the currently active entry.
-func (f *TrackingFacade) Stop() (types.Entry, error) {
+// Stop the currently active entry. If the given boundary's policy is to split entries, any time
+// tracked past the end of a day is moved onto continuation entries on the following days'
+// timesheets, and the last of those is returned.
+func (f *TrackingFacade) Stop(boundary types.DayBoundary) (_ types.Entry, err error) {
+	f.journal.Begin("Stop timer")
+	defer endOperation(f.journal, &err)
+
 	var entry types.Entry
 
 	status, err := f.sysGateway.FindOrCreateStatus()
@@ -89,11 +123,30 @@ func (f *TrackingFacade) Stop() (types.Entry, error) {
 		return entry, err
 	}
 
+	previous := committed(entry)
+
+	if boundary.Policy == types.MidnightSplit {
+		var sheet types.Timesheet
+
+		entry, sheet, err = f.split(entry, boundary)
+		if err != nil {
+			return entry, err
+		}
+
+		status.Start(sheet, entry)
+	}
+
+	// If the entry was split, the part being stopped was already recorded as it is now.
+	if previous.Hash != entry.Hash {
+		previous = entry
+	}
+
 	status.Stop()
 
 	errs := errhandling.NewErrorStack()
 	errs.Add(f.sysGateway.PersistStatus(status))
 	errs.Add(f.trGateway.PersistEntry(entry))
+	errs.Add(recordRevision(f.trGateway, f.clock, types.RevisionStop, previous, entry))
 
 	if err = errs.Errors(); err != nil {
 		return entry, err
@@ -103,8 +156,25 @@ func (f *TrackingFacade) Stop() (types.Entry, error) {
 }
 
 // Resume an entry with the given hash. If an empty hash is given, resume the currently active
-// timer. If no timer is active, error.
-func (f *TrackingFacade) Resume(hash string) (types.Entry, error) {
+// timer. If no timer is active, error. Resuming an entry from a previous day starts a continuation
+// entry on the current day's timesheet instead, unless the given policy is to extend entries.
+func (f *TrackingFacade) Resume(hash string, boundary types.DayBoundary, policy types.ResumePolicy) (types.Entry, error) {
+	return f.resume(hash, boundary, policy == types.ResumeContinue)
+}
+
+// ResumeFresh resumes an entry with the given hash in the same way as Resume, except that resuming
+// an entry from a previous day always starts a new entry, continuing it, on the current day's
+// timesheet, regardless of the resume policy.
+func (f *TrackingFacade) ResumeFresh(hash string, boundary types.DayBoundary) (types.Entry, error) {
+	return f.resume(hash, boundary, true)
+}
+
+// resume resumes an entry with the given hash. If continueOld is true, an entry from a previous day
+// is continued in a new entry on the current day's timesheet.
+func (f *TrackingFacade) resume(hash string, boundary types.DayBoundary, continueOld bool) (_ types.Entry, err error) {
+	f.journal.Begin("Resume timer")
+	defer endOperation(f.journal, &err)
+
 	var entry types.Entry
 
 	status, err := f.sysGateway.FindOrCreateStatus()
@@ -125,17 +195,34 @@ func (f *TrackingFacade) Resume(hash string) (types.Entry, error) {
 		return entry, err
 	}
 
+	now := f.clock.Now()
+
+	if continueOld && entry.Timesheet < boundary.Key(now) {
+		return f.continueEntry(status, entry, boundary.Key(now))
+	}
+
 	sheet, err := f.trGateway.FindOrCreateTimesheet(entry.Timesheet)
 	if err != nil {
 		return entry, err
 	}
 
+	previous := entry
+
+	// Resuming an entry that has already used up it's timebox leaves it running without one.
+	if entry.HasExpired() {
+		entry.Planned = 0
+		entry.PlannedBreak = 0
+	}
+
+	entry.MarkStarted(now)
+
 	status.Start(sheet, entry)
 
 	errs := errhandling.NewErrorStack()
 	errs.Add(f.sysGateway.PersistStatus(status))
 	errs.Add(f.trGateway.PersistEntry(entry))
 	errs.Add(f.trGateway.PersistTimesheet(sheet))
+	errs.Add(recordRevision(f.trGateway, f.clock, types.RevisionResume, previous, entry))
 
 	if err = errs.Errors(); err != nil {
 		return entry, err
@@ -143,3 +230,375 @@ func (f *TrackingFacade) Resume(hash string) (types.Entry, error) {
 
 	return entry, nil
 }
+
+// FindLatestByNote finds the most recent entry with the given note. If fuzzy is true, the note
+// only needs to roughly match the given text, regardless of case. Closer matches are preferred
+// over more recent ones: a whole note, then a whole word or the start of one, then anywhere in a
+// note, and finally notes containing the text's characters in order (e.g. "wai" matches "Working
+// on AI").
+func (f *TrackingFacade) FindLatestByNote(note string, fuzzy bool) (types.Entry, error) {
+	var best types.Entry
+	var bestScore int
+
+	keys, err := f.trGateway.FindTimesheetKeys()
+	if err != nil {
+		return best, err
+	}
+
+	for i := len(keys) - 1; i >= 0; i-- {
+		sheet, err := f.trGateway.FindTimesheet(keys[i])
+		if err != nil {
+			return best, err
+		}
+
+		for j := len(sheet.Entries) - 1; j >= 0; j-- {
+			entry := sheet.Entries[j]
+
+			if !fuzzy {
+				if entry.Note == note {
+					return entry, nil
+				}
+
+				continue
+			}
+
+			score := matchNote(entry.Note, note)
+			if score == matchWhole {
+				return entry, nil
+			}
+
+			if score > bestScore {
+				best = entry
+				bestScore = score
+			}
+		}
+	}
+
+	if bestScore == 0 {
+		return best, ErrNoMatchingEntry
+	}
+
+	return best, nil
+}
+
+// How closely a note matches some text, from not matching at all, to matching the whole note.
+const (
+	matchNone = iota
+	matchInOrder
+	matchAnywhere
+	matchWord
+	matchWhole
+)
+
+// matchNote returns how closely the given note matches the given text, regardless of case.
+func matchNote(note string, text string) int {
+	note = strings.ToLower(note)
+	text = strings.ToLower(text)
+
+	if note == text {
+		return matchWhole
+	}
+
+	score := matchNone
+
+	for offset := 0; offset < len(note); {
+		index := strings.Index(note[offset:], text)
+		if index < 0 {
+			break
+		}
+
+		index += offset
+		score = matchAnywhere
+
+		if before, _ := utf8.DecodeLastRuneInString(note[:index]); index == 0 || !unicode.IsLetter(before) && !unicode.IsDigit(before) {
+			return matchWord
+		}
+
+		offset = index + 1
+	}
+
+	if score == matchNone && containsInOrder(note, text) {
+		score = matchInOrder
+	}
+
+	return score
+}
+
+// containsInOrder returns true if all of the characters in the given text appear in the given
+// string, in the same order, though not necessarily next to each other.
+func containsInOrder(s string, text string) bool {
+	remaining := []rune(text)
+
+	for _, r := range s {
+		if len(remaining) == 0 {
+			break
+		}
+
+		if r == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+
+	return len(remaining) == 0
+}
+
+// continueEntry starts a new entry on the timesheet with the given key, continuing the given entry
+// from a previous day.
+func (f *TrackingFacade) continueEntry(status types.TrackingStatus, entry types.Entry, sheetKey string) (types.Entry, error) {
+	sheet, err := f.trGateway.FindOrCreateTimesheet(sheetKey)
+	if err != nil {
+		return entry, err
+	}
+
+	cont := types.NewEntry(f.clock.Now())
+	cont.Note = entry.Note
+	cont.Timesheet = sheet.Key
+	cont.Continues = entry.Hash
+	cont.IsRunning = true
+	cont.MarkStarted(cont.Created)
+
+	sheet.AppendEntry(cont)
+
+	status.Start(sheet, cont)
+
+	errs := errhandling.NewErrorStack()
+	errs.Add(f.sysGateway.PersistStatus(status))
+	errs.Add(f.trGateway.PersistEntry(cont))
+	errs.Add(f.trGateway.PersistTimesheet(sheet))
+	errs.Add(recordRevision(f.trGateway, f.clock, types.RevisionResume, types.Entry{}, cont))
+
+	if err = errs.Errors(); err != nil {
+		return cont, err
+	}
+
+	return cont, nil
+}
+
+// split splits the time a running entry has tracked since it's timer was last started at the end
+// of each tracking day that it ran past, moving the rest of the time onto continuation entries on
+// the following days' timesheets. That time is treated as having been tracked in one go from when
+// the timer was started, even if some of it was discarded (e.g. by ResolveIdle). The last part of
+// the entry, and the timesheet it belongs to, are returned. Continuation entries are not timeboxed.
+func (f *TrackingFacade) split(entry types.Entry, boundary types.DayBoundary) (types.Entry, types.Timesheet, error) {
+	start, startDuration := entry.LastStarted()
+
+	tracked := entry.Duration - startDuration
+	if tracked < 0 {
+		tracked = 0
+	}
+
+	end := start.Add(tracked)
+	cut := boundary.Next(start)
+
+	// If the timer was started after the entry's day had ended, none of the time belongs to it.
+	if boundary.Key(start) > entry.Timesheet {
+		cut = start
+	}
+
+	sheet, err := f.trGateway.FindOrCreateTimesheet(entry.Timesheet)
+	if err != nil || !cut.Before(end) {
+		return entry, sheet, err
+	}
+
+	previous := committed(entry)
+	entry.Duration = startDuration + cut.Sub(start)
+
+	errs := errhandling.NewErrorStack()
+	errs.Add(f.trGateway.PersistEntry(entry))
+	errs.Add(recordRevision(f.trGateway, f.clock, types.RevisionSplit, previous, entry))
+
+	if err = errs.Errors(); err != nil {
+		return entry, sheet, err
+	}
+
+	for cut.Before(end) {
+		next := boundary.Next(cut)
+		if next.After(end) {
+			next = end
+		}
+
+		sheet, err = f.trGateway.FindOrCreateTimesheet(boundary.Key(cut))
+		if err != nil {
+			return entry, sheet, err
+		}
+
+		cont := types.NewEntry(cut)
+		cont.Note = entry.Note
+		cont.Timesheet = sheet.Key
+		cont.MarkStarted(cut)
+		cont.Duration = next.Sub(cut)
+		cont.Continues = entry.Hash
+		cont.IsRunning = entry.IsRunning
+
+		sheet.AppendEntry(cont)
+
+		errs := errhandling.NewErrorStack()
+		errs.Add(f.trGateway.PersistEntry(cont))
+		errs.Add(f.trGateway.PersistTimesheet(sheet))
+		errs.Add(recordRevision(f.trGateway, f.clock, types.RevisionSplit, types.Entry{}, cont))
+
+		if err = errs.Errors(); err != nil {
+			return entry, sheet, err
+		}
+
+		entry = cont
+		cut = next
+	}
+
+	return entry, sheet, nil
+}
+
+// FindExpired finds the currently running entry, and reports whether or not it's timeboxed, and has
+// been tracked for at least it's planned duration. This does not modify anything.
+func (f *TrackingFacade) FindExpired() (types.Entry, bool, error) {
+	var entry types.Entry
+
+	status, err := f.sysGateway.FindOrCreateStatus()
+	if err != nil {
+		return entry, false, err
+	}
+
+	if !status.IsRunning {
+		return entry, false, nil
+	}
+
+	entry, err = f.trGateway.FindEntry(status.Entry)
+	if err != nil {
+		return entry, false, err
+	}
+
+	return entry, entry.HasExpired(), nil
+}
+
+// StopExpired stops the currently running entry if it's timeboxed, and has been tracked for at
+// least it's planned duration. The entry is stopped as of the time it expired, rather than now, and
+// a break entry is recorded if one was planned. Whether or not the entry was stopped is returned.
+func (f *TrackingFacade) StopExpired() (_ types.Entry, _ bool, err error) {
+	f.journal.Begin("Stop expired timer")
+	defer endOperation(f.journal, &err)
+
+	entry, expired, err := f.FindExpired()
+	if err != nil || !expired {
+		return entry, false, err
+	}
+
+	status, err := f.sysGateway.FindOrCreateStatus()
+	if err != nil {
+		return entry, false, err
+	}
+
+	overrun := entry.Overrun()
+	previous := committed(entry)
+
+	entry.Duration = entry.Planned
+	entry.Updated = entry.Updated.Add(-overrun)
+	entry.IsRunning = false
+
+	status.Stop()
+
+	errs := errhandling.NewErrorStack()
+	errs.Add(f.sysGateway.PersistStatus(status))
+	errs.Add(f.trGateway.PersistEntryAsOf(entry, entry.Updated))
+	errs.Add(recordRevision(f.trGateway, f.clock, types.RevisionStop, previous, entry))
+
+	if entry.PlannedBreak > 0 && overrun > 0 {
+		errs.Add(f.recordBreak(entry, overrun))
+	}
+
+	if err = errs.Errors(); err != nil {
+		return entry, false, err
+	}
+
+	return entry, true, nil
+}
+
+// recordBreak records a break entry on the given expired entry's timesheet, starting when it
+// expired, and lasting for up to it's planned break.
+func (f *TrackingFacade) recordBreak(entry types.Entry, overrun time.Duration) error {
+	sheet, err := f.trGateway.FindOrCreateTimesheet(entry.Timesheet)
+	if err != nil {
+		return err
+	}
+
+	duration := entry.PlannedBreak
+	if overrun < duration {
+		duration = overrun
+	}
+
+	brk := types.NewEntry(f.clock.Now())
+	brk.Note = BreakNote
+	brk.Timesheet = sheet.Key
+	brk.Created = entry.Updated
+	brk.Updated = entry.Updated.Add(duration)
+	brk.Duration = duration
+
+	sheet.AppendEntry(brk)
+
+	errs := errhandling.NewErrorStack()
+	errs.Add(f.trGateway.PersistEntryAsOf(brk, brk.Updated))
+	errs.Add(f.trGateway.PersistTimesheet(sheet))
+	errs.Add(recordRevision(f.trGateway, f.clock, types.RevisionCreate, types.Entry{}, brk))
+
+	return errs.Errors()
+}
+
+// FindIdle finds the currently running entry, and reports whether or not it has been running
+// without being observed for longer than the given maximum duration.
+func (f *TrackingFacade) FindIdle(max time.Duration) (types.Entry, bool, error) {
+	var entry types.Entry
+
+	status, err := f.sysGateway.FindOrCreateStatus()
+	if err != nil {
+		return entry, false, err
+	}
+
+	if !status.IsRunning {
+		return entry, false, nil
+	}
+
+	entry, err = f.trGateway.FindEntry(status.Entry)
+	if err != nil {
+		return entry, false, err
+	}
+
+	return entry, entry.IsIdle(max), nil
+}
+
+// ResolveIdle applies the given action to an idle entry found by FindIdle, adjusting how much of
+// the time it was left running for is kept, and persists it. The timer is left running.
+func (f *TrackingFacade) ResolveIdle(entry types.Entry, action types.IdleAction, max time.Duration) (_ types.Entry, err error) {
+	f.journal.Begin("Resolve idle timer")
+	defer endOperation(f.journal, &err)
+
+	previous := committed(entry)
+
+	switch action {
+	case types.IdleKeep:
+		// Nothing to do, the elapsed time has already been added.
+	case types.IdleDiscard:
+		entry.Duration = entry.Duration - entry.Elapsed
+
+		// None of the time since the timer was started is kept, so it's as if it started now.
+		entry.MarkStarted(entry.Updated)
+	case types.IdleCap:
+		if entry.Elapsed > max {
+			entry.Duration = entry.Duration - (entry.Elapsed - max)
+		}
+	default:
+		return entry, fmt.Errorf("tracking: Unknown idle action '%d'", action)
+	}
+
+	errs := errhandling.NewErrorStack()
+	errs.Add(f.trGateway.PersistEntry(entry))
+	errs.Add(recordRevision(f.trGateway, f.clock, types.RevisionIdle, previous, entry))
+
+	return entry, errs.Errors()
+}
+
+// committed returns the given entry as it was last persisted, without the time that has elapsed
+// since it was last observed.
+func committed(entry types.Entry) types.Entry {
+	entry.Duration = entry.Duration - entry.Elapsed
+
+	return entry
+}
@@ -0,0 +1,515 @@
+package util_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/types"
+	"github.com/SeerUK/tid/pkg/util"
+)
+
+func TestTrackingFacadeStartStop(t *testing.T) {
+	factory, _, _ := newTestFactory(t)
+	facade := factory.BuildTrackingFacade()
+
+	entry, err := facade.Start("Working on AI", 0, 0, types.DayBoundary{})
+	if err != nil {
+		t.Fatalf("unexpected error starting: %v", err)
+	}
+
+	if entry.Note != "Working on AI" {
+		t.Errorf("expected note %q, got %q", "Working on AI", entry.Note)
+	}
+
+	status, _ := factory.BuildSysGateway().FindOrCreateStatus()
+	if !status.IsRunning || status.Entry != entry.Hash || status.Timesheet != entry.Timesheet {
+		t.Errorf("expected status to track the started entry, got: %+v", status)
+	}
+
+	sheet, err := factory.BuildTrackingGateway().FindTimesheet(entry.Timesheet)
+	if err != nil {
+		t.Fatalf("unexpected error finding timesheet: %v", err)
+	}
+
+	if len(sheet.Entries) != 1 || sheet.Entries[0].Hash != entry.Hash {
+		t.Errorf("expected timesheet to contain the started entry, got: %+v", sheet.Entries)
+	}
+
+	if _, err := facade.Start("Another", 0, 0, types.DayBoundary{}); err != util.ErrTimerRunning {
+		t.Errorf("expected ErrTimerRunning starting a second timer, got: %v", err)
+	}
+
+	stopped, err := facade.Stop(types.DayBoundary{})
+	if err != nil {
+		t.Fatalf("unexpected error stopping: %v", err)
+	}
+
+	if stopped.Hash != entry.Hash {
+		t.Errorf("expected to stop entry %s, got %s", entry.Hash, stopped.Hash)
+	}
+
+	status, _ = factory.BuildSysGateway().FindOrCreateStatus()
+	if status.IsRunning || status.Entry != entry.Hash {
+		t.Errorf("expected status to be stopped, and still reference the entry, got: %+v", status)
+	}
+
+	if _, err := facade.Stop(types.DayBoundary{}); err != util.ErrNoTimerRunning {
+		t.Errorf("expected ErrNoTimerRunning stopping again, got: %v", err)
+	}
+}
+
+func TestTrackingFacadeStartStopLog(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildTrackingFacade()
+
+	entry, _ := facade.Start("Client work", 0, 0, types.DayBoundary{})
+
+	clock.Advance(time.Hour)
+	facade.Stop(types.DayBoundary{})
+
+	facade.Resume(entry.Hash, types.DayBoundary{}, types.ResumeContinue)
+
+	clock.Advance(30 * time.Minute)
+	facade.Stop(types.DayBoundary{})
+
+	log, err := factory.BuildTrackingGateway().FindOrCreateEntryLog(entry.Hash)
+	if err != nil {
+		t.Fatalf("unexpected error finding log: %v", err)
+	}
+
+	expected := []struct {
+		action   string
+		previous time.Duration
+		duration time.Duration
+	}{
+		{types.RevisionStart, 0, 0},
+		{types.RevisionStop, 0, time.Hour},
+		{types.RevisionResume, time.Hour, time.Hour},
+		{types.RevisionStop, time.Hour, 90 * time.Minute},
+	}
+
+	if len(log.Revisions) != len(expected) {
+		t.Fatalf("expected %d revisions, got: %+v", len(expected), log.Revisions)
+	}
+
+	for i, revision := range log.Revisions {
+		if revision.Action != expected[i].action || revision.PreviousDuration != expected[i].previous || revision.Duration != expected[i].duration {
+			t.Errorf("expected revision %d to be %+v, got %+v", i, expected[i], revision)
+		}
+	}
+}
+
+func TestTrackingFacadeResume(t *testing.T) {
+	factory, _, _ := newTestFactory(t)
+	facade := factory.BuildTrackingFacade()
+
+	if _, err := facade.Resume("", types.DayBoundary{}, types.ResumeContinue); err == nil {
+		t.Error("expected an error resuming with no previous timer")
+	}
+
+	first, _ := facade.Start("First", 0, 0, types.DayBoundary{})
+	facade.Stop(types.DayBoundary{})
+	second, _ := facade.Start("Second", 0, 0, types.DayBoundary{})
+	facade.Stop(types.DayBoundary{})
+
+	resumed, err := facade.Resume("", types.DayBoundary{}, types.ResumeContinue)
+	if err != nil {
+		t.Fatalf("unexpected error resuming: %v", err)
+	}
+
+	if resumed.Hash != second.Hash {
+		t.Errorf("expected to resume the most recent entry %s, got %s", second.Hash, resumed.Hash)
+	}
+
+	facade.Stop(types.DayBoundary{})
+
+	resumed, err = facade.Resume(first.ShortHash(), types.DayBoundary{}, types.ResumeContinue)
+	if err != nil {
+		t.Fatalf("unexpected error resuming by short hash: %v", err)
+	}
+
+	if resumed.Hash != first.Hash {
+		t.Errorf("expected to resume entry %s, got %s", first.Hash, resumed.Hash)
+	}
+
+	status, _ := factory.BuildSysGateway().FindOrCreateStatus()
+	if !status.IsRunning || status.Entry != first.Hash {
+		t.Errorf("expected status to track the resumed entry, got: %+v", status)
+	}
+}
+
+func TestTrackingFacadeStopExpired(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildTrackingFacade()
+
+	entry, _ := facade.Start("Timeboxed", 5*time.Second, 3*time.Second, types.DayBoundary{})
+
+	clock.Advance(4 * time.Second)
+
+	if _, expired, _ := facade.FindExpired(); expired {
+		t.Fatal("expected the timeboxed entry not to have expired yet")
+	}
+
+	clock.Advance(6 * time.Second)
+
+	stopped, ok, err := facade.StopExpired()
+	if err != nil {
+		t.Fatalf("unexpected error stopping expired timer: %v", err)
+	}
+
+	if !ok {
+		t.Fatal("expected the expired timer to be stopped")
+	}
+
+	if stopped.Duration != 5*time.Second {
+		t.Errorf("expected duration to be capped at the planned 5s, got %s", stopped.Duration)
+	}
+
+	if expected := entry.Created.Add(5 * time.Second); !stopped.Updated.Equal(expected) {
+		t.Errorf("expected timer to be stopped at %s, got %s", expected, stopped.Updated)
+	}
+
+	status, _ := factory.BuildSysGateway().FindOrCreateStatus()
+	if status.IsRunning {
+		t.Error("expected status to be stopped")
+	}
+
+	sheet, _ := factory.BuildTrackingGateway().FindTimesheet(entry.Timesheet)
+	if len(sheet.Entries) != 2 {
+		t.Fatalf("expected a break entry to be recorded, got: %+v", sheet.Entries)
+	}
+
+	brk := sheet.Entries[1]
+	if brk.Note != util.BreakNote || brk.Duration != 3*time.Second {
+		t.Errorf("expected a 3s break entry, got %q for %s", brk.Note, brk.Duration)
+	}
+
+	if !brk.Created.Equal(stopped.Updated) {
+		t.Errorf("expected the break to start when the timer stopped, got %s", brk.Created)
+	}
+
+	stored, _ := factory.BuildTrackingGateway().FindEntry(entry.Hash)
+	if !stored.Updated.Equal(stopped.Updated) {
+		t.Errorf("expected the stored timer to be stopped at %s, got %s", stopped.Updated, stored.Updated)
+	}
+
+	if expected := stopped.Updated.Add(3 * time.Second); !brk.Updated.Equal(expected) {
+		t.Errorf("expected the stored break to end at %s, got %s", expected, brk.Updated)
+	}
+
+	if _, ok, _ := facade.StopExpired(); ok {
+		t.Error("expected nothing to stop once the timer is stopped")
+	}
+}
+
+func TestTrackingFacadeResolveIdle(t *testing.T) {
+	tests := []struct {
+		action   types.IdleAction
+		expected time.Duration
+	}{
+		{types.IdleKeep, 2 * time.Hour},
+		{types.IdleDiscard, 0},
+		{types.IdleCap, time.Hour},
+	}
+
+	for _, test := range tests {
+		factory, _, clock := newTestFactory(t)
+		facade := factory.BuildTrackingFacade()
+
+		facade.Start("Forgotten", 0, 0, types.DayBoundary{})
+
+		clock.Advance(2 * time.Hour)
+
+		idle, ok, err := facade.FindIdle(time.Hour)
+		if err != nil || !ok {
+			t.Fatalf("%s: expected the timer to be idle, got %v, %v", test.action, ok, err)
+		}
+
+		resolved, err := facade.ResolveIdle(idle, test.action, time.Hour)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.action, err)
+		}
+
+		if resolved.Duration != test.expected {
+			t.Errorf("%s: expected duration %s, got %s", test.action, test.expected, resolved.Duration)
+		}
+	}
+}
+
+func TestTrackingFacadeMidnight(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildTrackingFacade()
+
+	clock.Set(time.Date(2017, 3, 1, 23, 59, 30, 0, time.Local))
+
+	before, _ := facade.Start("Late night", 0, 0, types.DayBoundary{})
+
+	clock.Advance(time.Minute)
+
+	stopped, err := facade.Stop(types.DayBoundary{})
+	if err != nil {
+		t.Fatalf("unexpected error stopping: %v", err)
+	}
+
+	if stopped.Timesheet != "2017-03-01" || stopped.Duration != time.Minute {
+		t.Errorf("expected 1m on the 2017-03-01 timesheet, got %s on %s", stopped.Duration, stopped.Timesheet)
+	}
+
+	after, _ := facade.Start("Early morning", 0, 0, types.DayBoundary{})
+
+	if before.Timesheet != "2017-03-01" || after.Timesheet != "2017-03-02" {
+		t.Errorf("expected entries on consecutive timesheets, got %s and %s", before.Timesheet, after.Timesheet)
+	}
+}
+
+func TestTrackingFacadeMidnightSplit(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildTrackingFacade()
+	trGateway := factory.BuildTrackingGateway()
+
+	boundary := types.DayBoundary{Policy: types.MidnightSplit}
+
+	clock.Set(time.Date(2017, 3, 1, 23, 0, 0, 0, time.Local))
+
+	started, _ := facade.Start("Late night", 0, 0, boundary)
+
+	clock.Set(time.Date(2017, 3, 2, 2, 0, 0, 0, time.Local))
+
+	stopped, err := facade.Stop(boundary)
+	if err != nil {
+		t.Fatalf("unexpected error stopping: %v", err)
+	}
+
+	if stopped.Timesheet != "2017-03-02" || stopped.Duration != 2*time.Hour {
+		t.Errorf("expected 2h on the 2017-03-02 timesheet, got %s on %s", stopped.Duration, stopped.Timesheet)
+	}
+
+	if stopped.Continues != started.Hash || stopped.Note != started.Note {
+		t.Errorf("expected the continuation to be linked to %s, got %q", started.Hash, stopped.Continues)
+	}
+
+	first, _ := trGateway.FindEntry(started.Hash)
+	if first.Duration != time.Hour {
+		t.Errorf("expected 1h left on the 2017-03-01 timesheet, got %s", first.Duration)
+	}
+
+	sheet, _ := trGateway.FindTimesheet("2017-03-02")
+	if len(sheet.Entries) != 1 || sheet.Entries[0].Hash != stopped.Hash {
+		t.Errorf("expected the continuation on the 2017-03-02 timesheet, got %v", sheet.Entries)
+	}
+
+	status, _ := factory.BuildSysGateway().FindOrCreateStatus()
+	if status.Entry != stopped.Hash || status.IsRunning {
+		t.Errorf("expected the stopped continuation to be the current entry, got %s", status.Entry)
+	}
+}
+
+func TestTrackingFacadeMidnightSplitAfterIdle(t *testing.T) {
+	boundary := types.DayBoundary{Policy: types.MidnightSplit}
+
+	tests := []struct {
+		action types.IdleAction
+		first  time.Duration
+		last   time.Duration
+	}{
+		{types.IdleKeep, 2 * time.Hour, 10 * time.Hour},
+		{types.IdleCap, 2 * time.Hour, 3*time.Hour + 30*time.Minute},
+		{types.IdleDiscard, 30 * time.Minute, time.Hour},
+	}
+
+	for _, test := range tests {
+		factory, _, clock := newTestFactory(t)
+		facade := factory.BuildTrackingFacade()
+
+		clock.Set(time.Date(2017, 3, 1, 22, 0, 0, 0, time.Local))
+
+		started, _ := facade.Start("Overnight", 0, 0, boundary)
+
+		// Changing the note writes the entry while it's running, which mustn't move the split.
+		clock.Set(time.Date(2017, 3, 1, 22, 30, 0, 0, time.Local))
+		factory.BuildEntryFacade().UpdateNote(started.Hash, "Overnight work")
+
+		clock.Set(time.Date(2017, 3, 2, 9, 0, 0, 0, time.Local))
+
+		idle, _, _ := facade.FindIdle(4 * time.Hour)
+		if _, err := facade.ResolveIdle(idle, test.action, 4*time.Hour); err != nil {
+			t.Fatalf("unexpected error resolving idle timer with %s: %v", test.action, err)
+		}
+
+		clock.Set(time.Date(2017, 3, 2, 10, 0, 0, 0, time.Local))
+
+		stopped, err := facade.Stop(boundary)
+		if err != nil {
+			t.Fatalf("unexpected error stopping: %v", err)
+		}
+
+		first, _ := factory.BuildTrackingGateway().FindEntry(started.Hash)
+		if first.Duration != test.first {
+			t.Errorf("expected %s left on the 2017-03-01 timesheet with %s, got %s", test.first, test.action, first.Duration)
+		}
+
+		if stopped.Timesheet != "2017-03-02" || stopped.Continues != started.Hash || stopped.Duration != test.last {
+			t.Errorf("expected a %s continuation on the 2017-03-02 timesheet with %s, got %s on %s", test.last, test.action, stopped.Duration, stopped.Timesheet)
+		}
+	}
+}
+
+func TestTrackingFacadeDayRollover(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildTrackingFacade()
+
+	boundary := types.DayBoundary{Policy: types.MidnightSplit, Rollover: 4 * time.Hour}
+
+	clock.Set(time.Date(2017, 3, 1, 23, 0, 0, 0, time.Local))
+
+	started, _ := facade.Start("Late night", 0, 0, boundary)
+
+	clock.Set(time.Date(2017, 3, 2, 2, 0, 0, 0, time.Local))
+
+	stopped, _ := facade.Stop(boundary)
+	if stopped.Hash != started.Hash || stopped.Duration != 3*time.Hour {
+		t.Errorf("expected 3h on the original entry, got %s on %s", stopped.Duration, stopped.Timesheet)
+	}
+
+	early, _ := facade.Start("Early morning", 0, 0, boundary)
+	if early.Timesheet != "2017-03-01" {
+		t.Errorf("expected an entry started before the rollover on 2017-03-01, got %s", early.Timesheet)
+	}
+
+	clock.Set(time.Date(2017, 3, 2, 5, 30, 0, 0, time.Local))
+
+	stopped, _ = facade.Stop(boundary)
+	if stopped.Timesheet != "2017-03-02" || stopped.Duration != 90*time.Minute {
+		t.Errorf("expected 1h30m on the 2017-03-02 timesheet, got %s on %s", stopped.Duration, stopped.Timesheet)
+	}
+}
+
+func TestTrackingFacadeResumeContinues(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildTrackingFacade()
+
+	split := types.DayBoundary{Policy: types.MidnightSplit}
+
+	entry, _ := facade.Start("Yesterday", 0, 0, split)
+	clock.Advance(time.Hour)
+	facade.Stop(split)
+
+	clock.Set(time.Date(2017, 3, 2, 9, 0, 0, 0, time.Local))
+
+	resumed, err := facade.Resume(entry.ShortHash(), split, types.ResumeContinue)
+	if err != nil {
+		t.Fatalf("unexpected error resuming: %v", err)
+	}
+
+	if resumed.Hash == entry.Hash || resumed.Continues != entry.Hash || resumed.Timesheet != "2017-03-02" {
+		t.Errorf("expected a continuation on the 2017-03-02 timesheet, got %+v", resumed)
+	}
+
+	facade.Stop(split)
+
+	continued, _ := facade.Resume(entry.ShortHash(), types.DayBoundary{}, types.ResumeContinue)
+	if continued.Hash == entry.Hash || continued.Continues != entry.Hash || continued.Timesheet != "2017-03-02" {
+		t.Errorf("expected a continuation on the 2017-03-02 timesheet by default, got %+v", continued)
+	}
+
+	facade.Stop(types.DayBoundary{})
+
+	sheet, _ := factory.BuildTrackingGateway().FindOrCreateTimesheet("2017-03-01")
+	if len(sheet.Entries) != 1 || sheet.Entries[0].Duration != time.Hour {
+		t.Errorf("expected the 2017-03-01 timesheet to be unchanged, got %+v", sheet.Entries)
+	}
+
+	kept, _ := facade.Resume(entry.ShortHash(), types.DayBoundary{}, types.ResumeExtend)
+	if kept.Hash != entry.Hash || kept.Timesheet != "2017-03-01" {
+		t.Errorf("expected the original entry to be resumed when extending, got %+v", kept)
+	}
+
+	facade.Stop(types.DayBoundary{})
+
+	// Splitting at midnight still moves time tracked on an extended entry after it's day has ended
+	// onto a continuation, once the timer stops.
+	extended, _ := facade.Resume(entry.ShortHash(), split, types.ResumeExtend)
+	if extended.Hash != entry.Hash {
+		t.Errorf("expected the original entry to be resumed when extending, got %+v", extended)
+	}
+
+	clock.Advance(time.Hour)
+
+	stopped, _ := facade.Stop(split)
+	if stopped.Hash == entry.Hash || stopped.Timesheet != "2017-03-02" || stopped.Duration != time.Hour {
+		t.Errorf("expected 1h on a continuation on the 2017-03-02 timesheet, got %+v", stopped)
+	}
+}
+
+func TestTrackingFacadeFindLatestByNote(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildTrackingFacade()
+	boundary := types.DayBoundary{}
+
+	older, _ := facade.Start("Working on AI", 0, 0, boundary)
+	facade.Stop(boundary)
+
+	clock.Set(time.Date(2017, 3, 2, 9, 0, 0, 0, time.Local))
+
+	email, _ := facade.Start("Email", 0, 0, boundary)
+	facade.Stop(boundary)
+
+	newer, _ := facade.Start("Working on AI", 0, 0, boundary)
+	facade.Stop(boundary)
+
+	tests := []struct {
+		note  string
+		fuzzy bool
+		hash  string
+	}{
+		{"Working on AI", false, newer.Hash},
+		{"working on ai", true, newer.Hash},
+		{"ai", true, newer.Hash},
+		{"mai", true, email.Hash},
+		{"wrk", true, newer.Hash},
+	}
+
+	for _, test := range tests {
+		found, err := facade.FindLatestByNote(test.note, test.fuzzy)
+		if err != nil || found.Hash != test.hash {
+			t.Errorf("expected %q (fuzzy: %t) to find %s, got %s, %v", test.note, test.fuzzy, test.hash, found.Hash, err)
+		}
+	}
+
+	if _, err := facade.FindLatestByNote("working on ai", false); err != util.ErrNoMatchingEntry {
+		t.Errorf("expected ErrNoMatchingEntry when the note isn't exact, got: %v", err)
+	}
+
+	if _, err := facade.FindLatestByNote("xyz", true); err != util.ErrNoMatchingEntry {
+		t.Errorf("expected ErrNoMatchingEntry when nothing matches, got: %v", err)
+	}
+
+	resumed, err := facade.ResumeFresh(older.Hash, boundary)
+	if err != nil {
+		t.Fatalf("unexpected error resuming: %v", err)
+	}
+
+	if resumed.Hash == older.Hash || resumed.Continues != older.Hash || resumed.Timesheet != "2017-03-02" || resumed.Note != older.Note {
+		t.Errorf("expected a new entry on the 2017-03-02 timesheet, got %+v", resumed)
+	}
+}
+
+func TestTrackingFacadeSubSecondPrecision(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildTrackingFacade()
+
+	boundary := types.DayBoundary{}
+
+	entry, _ := facade.Start("Short bursts", 0, 0, boundary)
+
+	for i := 0; i < 4; i++ {
+		clock.Advance(1500 * time.Millisecond)
+		facade.Stop(boundary)
+		facade.Resume(entry.Hash, boundary, types.ResumeContinue)
+	}
+
+	stopped, _ := facade.Stop(boundary)
+	if stopped.Duration != 6*time.Second {
+		t.Errorf("expected no time to be lost to rounding, got %s", stopped.Duration)
+	}
+}
@@ -13,18 +13,24 @@ type WorkspaceFacade struct {
 	backend state.Backend
 	// sysGateway is a SysGateway used for accessing system storage.
 	sysGateway state.SysGateway
+	// journal is a Journal used to record changes, so that they can be undone.
+	journal state.Journal
 }
 
 // NewWorkspaceFacade creates a new WorkspaceFacade instance.
-func NewWorkspaceFacade(backend state.Backend, sysGateway state.SysGateway) *WorkspaceFacade {
+func NewWorkspaceFacade(backend state.Backend, sysGateway state.SysGateway, journal state.Journal) *WorkspaceFacade {
 	return &WorkspaceFacade{
 		backend:    backend,
 		sysGateway: sysGateway,
+		journal:    journal,
 	}
 }
 
 // Create attempts to create a new workspace.
-func (f *WorkspaceFacade) Create(name string) error {
+func (f *WorkspaceFacade) Create(name string) (err error) {
+	f.journal.Begin(fmt.Sprintf("Create workspace '%s'", name))
+	defer endOperation(f.journal, &err)
+
 	index, err := f.sysGateway.FindWorkspaceIndex()
 	if err != nil {
 		return err
@@ -52,7 +58,10 @@ func (f *WorkspaceFacade) Create(name string) error {
 }
 
 // Delete attempts to delete a workspace.
-func (f *WorkspaceFacade) Delete(name string) error {
+func (f *WorkspaceFacade) Delete(name string) (err error) {
+	f.journal.Begin(fmt.Sprintf("Delete workspace '%s'", name))
+	defer endOperation(f.journal, &err)
+
 	index, err := f.sysGateway.FindWorkspaceIndex()
 	if err != nil {
 		return err
@@ -85,7 +94,10 @@ func (f *WorkspaceFacade) Delete(name string) error {
 }
 
 // Switch attempts to switch to another workspace.
-func (f *WorkspaceFacade) Switch(name string) error {
+func (f *WorkspaceFacade) Switch(name string) (err error) {
+	f.journal.Begin(fmt.Sprintf("Switch to workspace '%s'", name))
+	defer endOperation(f.journal, &err)
+
 	index, err1 := f.sysGateway.FindWorkspaceIndex()
 	status, err2 := f.sysGateway.FindOrCreateStatus()
 
@@ -97,7 +109,7 @@ func (f *WorkspaceFacade) Switch(name string) error {
 		return errs.Errors()
 	}
 
-	status, err := f.sysGateway.FindOrCreateStatus()
+	status, err = f.sysGateway.FindOrCreateStatus()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,92 @@
+package util_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SeerUK/tid/pkg/state"
+	"github.com/SeerUK/tid/pkg/types"
+)
+
+func TestWorkspaceFacadeCreate(t *testing.T) {
+	factory, backend, _ := newTestFactory(t)
+	facade := factory.BuildWorkspaceFacade()
+
+	if err := facade.Create("freelance"); err != nil {
+		t.Fatalf("unexpected error creating workspace: %v", err)
+	}
+
+	if !backend.HasBucket(fmt.Sprintf(state.BackendBucketWorkspaceFmt, "freelance")) {
+		t.Error("expected a bucket to be created for the workspace")
+	}
+
+	index, _ := factory.BuildSysGateway().FindWorkspaceIndex()
+	if expected := []string{types.TrackingStatusDefaultWorkspace, "freelance"}; !reflect.DeepEqual(index.Workspaces, expected) {
+		t.Errorf("expected workspaces %v, got %v", expected, index.Workspaces)
+	}
+
+	if err := facade.Create("freelance"); err == nil {
+		t.Error("expected an error creating a workspace that already exists")
+	}
+}
+
+func TestWorkspaceFacadeSwitch(t *testing.T) {
+	factory, _, clock := newTestFactory(t)
+	facade := factory.BuildWorkspaceFacade()
+
+	facade.Create("freelance")
+
+	entry, _ := factory.BuildTrackingFacade().Start("Default work", 0, 0, types.DayBoundary{})
+
+	if err := facade.Switch("missing"); err == nil {
+		t.Error("expected an error switching to a workspace that doesn't exist")
+	}
+
+	if err := facade.Switch("freelance"); err != nil {
+		t.Fatalf("unexpected error switching workspace: %v", err)
+	}
+
+	status, _ := factory.BuildSysGateway().FindOrCreateStatus()
+	if status.Workspace != "freelance" || status.IsRunning || status.Entry != "" {
+		t.Errorf("expected switching to stop the timer, and change workspace, got: %+v", status)
+	}
+
+	// Tracking data is separate for each workspace.
+	if _, err := factory.BuildTrackingGateway().FindEntry(entry.Hash); err != state.ErrStoreNilResult {
+		t.Errorf("expected entry from the default workspace not to be found, got: %v", err)
+	}
+
+	factory.BuildEntryFacade().Create(clock.Now(), time.Hour, "Freelance work")
+
+	facade.Switch(types.TrackingStatusDefaultWorkspace)
+
+	if _, err := factory.BuildTrackingGateway().FindEntry(entry.Hash); err != nil {
+		t.Errorf("expected entry to be found after switching back, got: %v", err)
+	}
+}
+
+func TestWorkspaceFacadeDelete(t *testing.T) {
+	factory, backend, _ := newTestFactory(t)
+	facade := factory.BuildWorkspaceFacade()
+
+	facade.Create("freelance")
+
+	if err := facade.Delete("freelance"); err != nil {
+		t.Fatalf("unexpected error deleting workspace: %v", err)
+	}
+
+	if backend.HasBucket(fmt.Sprintf(state.BackendBucketWorkspaceFmt, "freelance")) {
+		t.Error("expected the workspace's bucket to be deleted")
+	}
+
+	index, _ := factory.BuildSysGateway().FindWorkspaceIndex()
+	if expected := []string{types.TrackingStatusDefaultWorkspace}; !reflect.DeepEqual(index.Workspaces, expected) {
+		t.Errorf("expected workspaces %v, got %v", expected, index.Workspaces)
+	}
+
+	if err := facade.Delete("freelance"); err == nil {
+		t.Error("expected an error deleting a workspace that doesn't exist")
+	}
+}
@@ -0,0 +1,103 @@
+package xtime
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time. Code that depends on the current time should use a Clock, so
+// that it's behaviour can be tested, or reproduced, at any time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// systemClock is a Clock that uses the system's time.
+type systemClock struct{}
+
+// NewSystemClock creates a new Clock that uses the system's time.
+func NewSystemClock() Clock {
+	return systemClock{}
+}
+
+func (c systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// offsetClock is a Clock that runs at the same rate as the system's time, but from a different
+// starting time.
+type offsetClock struct {
+	// offset is the difference between the system's time and this clock's time.
+	offset time.Duration
+}
+
+// NewOffsetClock creates a new Clock that starts at the given time, and keeps running from there.
+func NewOffsetClock(now time.Time) Clock {
+	return offsetClock{
+		offset: now.Sub(time.Now()),
+	}
+}
+
+func (c offsetClock) Now() time.Time {
+	return time.Now().Add(c.offset)
+}
+
+// locationClock is a Clock that returns the time from another Clock in a specific time zone.
+type locationClock struct {
+	// clock is the Clock the time comes from.
+	clock Clock
+	// location is the time zone the time is returned in.
+	location *time.Location
+}
+
+// NewLocationClock creates a new Clock that returns the time from the given Clock, in the given time
+// zone.
+func NewLocationClock(clock Clock, location *time.Location) Clock {
+	return locationClock{
+		clock:    clock,
+		location: location,
+	}
+}
+
+func (c locationClock) Now() time.Time {
+	return c.clock.Now().In(c.location)
+}
+
+// FakeClock is a Clock that only changes time when it's told to, for use in tests.
+type FakeClock struct {
+	sync.Mutex
+
+	// now is the current time of this clock.
+	now time.Time
+}
+
+// NewFakeClock creates a new FakeClock, stopped at the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now: now,
+	}
+}
+
+// Now returns the time this clock is stopped at.
+func (c *FakeClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.now
+}
+
+// Set stops this clock at the given time.
+func (c *FakeClock) Set(now time.Time) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.now = now
+}
+
+// Advance moves this clock forward by the given duration.
+func (c *FakeClock) Advance(duration time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.now = c.now.Add(duration)
+}
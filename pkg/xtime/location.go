@@ -0,0 +1,109 @@
+package xtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Location is a type used to extend the built-in 'time.Location' type so that time zones can be
+// parsed from configuration files and console options by name (e.g. "Europe/London", "UTC", or
+// "local" for the system's time zone). The zero value is an unset Location.
+type Location struct {
+	location *time.Location
+}
+
+// ParseLocation attempts to parse the given string as the name of a Location.
+func ParseLocation(text string) (Location, error) {
+	if strings.ToLower(text) == "local" {
+		return Location{location: time.Local}, nil
+	}
+
+	location, err := time.LoadLocation(text)
+	if err != nil || text == "" {
+		return Location{}, fmt.Errorf("xtime: Invalid Location '%s'", text)
+	}
+
+	return Location{location: location}, nil
+}
+
+// IsSet returns true if this Location has been given a time zone.
+func (l Location) IsSet() bool {
+	return l.location != nil
+}
+
+// TimeLocation converts this Location to a standard library time.Location, falling back to the
+// system's time zone if it's not set.
+func (l Location) TimeLocation() *time.Location {
+	if l.location == nil {
+		return time.Local
+	}
+
+	return l.location
+}
+
+// UnmarshalTOML takes a raw TOML time zone string value and attempts to parse the value as a
+// Location. The value passed to this method should be a byte array of a quoted string (i.e. the raw
+// TOML value), the method will remove the quotes.
+func (l *Location) UnmarshalTOML(bytes []byte) error {
+	text, err := strconv.Unquote(string(bytes))
+	if err != nil {
+		return err
+	}
+
+	return l.Set(text)
+}
+
+// Set parses the given string as a Location and sets it on this Location. This allows a Location to
+// be used as a console parameter value.
+func (l *Location) Set(text string) error {
+	location, err := ParseLocation(text)
+	if err != nil {
+		return err
+	}
+
+	*l = location
+
+	return nil
+}
+
+// String returns the name of this Location.
+func (l Location) String() string {
+	if l.location == nil {
+		return ""
+	}
+
+	return l.location.String()
+}
+
+// ZoneName returns the name of the time zone the given time is in, and it's offset from UTC in
+// seconds, so that it can be stored and found again with LoadZone. The system's time zone is stored
+// by it's abbreviation, as it has no name of it's own.
+func ZoneName(t time.Time) (string, int) {
+	name, offset := t.Zone()
+
+	if location := t.Location(); location != time.Local {
+		name = location.String()
+	}
+
+	return name, offset
+}
+
+// LoadZone returns the time zone with the given name, as stored by ZoneName at the given time. If
+// no time zone with that name has the same offset at that time (e.g. because the name was an
+// abbreviation), a fixed zone with the given offset is returned instead. An empty name returns the
+// system's time zone.
+func LoadZone(name string, offset int, at time.Time) *time.Location {
+	if name == "" {
+		return time.Local
+	}
+
+	if location, err := time.LoadLocation(name); err == nil {
+		if _, actual := at.In(location).Zone(); actual == offset {
+			return location
+		}
+	}
+
+	return time.FixedZone(name, offset)
+}
@@ -0,0 +1,57 @@
+package xtime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestZoneNameLoadZone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	tests := []struct {
+		at       time.Time
+		expected string
+	}{
+		{time.Date(2017, 3, 1, 9, 0, 0, 0, tokyo), "Asia/Tokyo"},
+		{time.Date(2017, 3, 1, 9, 0, 0, 0, time.FixedZone("XYZ", 5400)), "XYZ"},
+	}
+
+	for _, test := range tests {
+		name, offset := ZoneName(test.at)
+		if name != test.expected {
+			t.Errorf("expected zone name %s, got %s", test.expected, name)
+		}
+
+		loaded := test.at.In(LoadZone(name, offset, test.at))
+		if loaded.Format(time.RFC3339) != test.at.Format(time.RFC3339) {
+			t.Errorf("expected %s after loading zone %s, got %s", test.at, name, loaded)
+		}
+	}
+
+	if location := LoadZone("", 0, time.Now()); location != time.Local {
+		t.Errorf("expected an empty zone name to load the system's time zone, got %s", location)
+	}
+}
+
+func TestParseLocation(t *testing.T) {
+	if location, err := ParseLocation("local"); err != nil || location.TimeLocation() != time.Local {
+		t.Errorf("expected local to be the system's time zone, got %s, %v", location, err)
+	}
+
+	if location, err := ParseLocation("UTC"); err != nil || location.TimeLocation() != time.UTC {
+		t.Errorf("expected UTC, got %s, %v", location, err)
+	}
+
+	for _, text := range []string{"", "Nowhere/Special"} {
+		if _, err := ParseLocation(text); err == nil {
+			t.Errorf("expected an error parsing %q", text)
+		}
+	}
+
+	if location := (Location{}); location.IsSet() || location.TimeLocation() != time.Local {
+		t.Error("expected an unset Location to fall back to the system's time zone")
+	}
+}
@@ -19,11 +19,31 @@ type DurationFormat int
 const (
 	FormatDecimal DurationFormat = iota
 	FormatText
+	FormatClock
+	FormatClockSeconds
+	FormatMinutes
+	FormatISO8601
 )
 
 var formats = map[string]DurationFormat{
-	"decimal": FormatDecimal,
-	"text":    FormatText,
+	"decimal":       FormatDecimal,
+	"text":          FormatText,
+	"clock":         FormatClock,
+	"clock-seconds": FormatClockSeconds,
+	"minutes":       FormatMinutes,
+	"iso8601":       FormatISO8601,
+}
+
+// ParseDurationFormat attempts to parse the given string as a DurationFormat.
+func ParseDurationFormat(text string) (DurationFormat, error) {
+	text = strings.ToLower(text)
+
+	format, ok := formats[text]
+	if !ok {
+		return format, fmt.Errorf("xtime: Invalid DurationFormat '%s'", text)
+	}
+
+	return format, nil
 }
 
 // UnmarshalTOML takes a raw TOML time format value and attempts to parse the value as a
@@ -35,18 +55,168 @@ func (f *DurationFormat) UnmarshalTOML(bytes []byte) error {
 		return err
 	}
 
+	return f.Set(text)
+}
+
+// Set parses the given string as a DurationFormat and sets it on this DurationFormat. This allows
+// a DurationFormat to be used as a console parameter value.
+func (f *DurationFormat) Set(text string) error {
+	format, err := ParseDurationFormat(text)
+	if err != nil {
+		return err
+	}
+
+	*f = format
+
+	return nil
+}
+
+// String returns the name of this DurationFormat.
+func (f DurationFormat) String() string {
+	for name, format := range formats {
+		if format == f {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// Duration is a type used to extend the built-in 'time.Duration' type so that durations can be
+// parsed from configuration files (e.g. "15m", "1h30m").
+type Duration time.Duration
+
+// TimeDuration converts this Duration to a standard library time.Duration.
+func (d Duration) TimeDuration() time.Duration {
+	return time.Duration(d)
+}
+
+// UnmarshalTOML takes a raw TOML duration string value and attempts to parse the value as a
+// Duration. The value passed to this method should be a byte array of a quoted string (i.e. the raw
+// TOML value), the method will remove the quotes.
+func (d *Duration) UnmarshalTOML(bytes []byte) error {
+	text, err := strconv.Unquote(string(bytes))
+	if err != nil {
+		return err
+	}
+
+	duration, err := time.ParseDuration(text)
+	if err != nil {
+		return fmt.Errorf("xtime: Invalid Duration '%s'", text)
+	}
+
+	*d = Duration(duration)
+
+	return nil
+}
+
+// RoundingMode is an "enum" of the different ways a duration can be rounded to an increment.
+type RoundingMode int
+
+// All possible rounding modes.
+const (
+	RoundNearest RoundingMode = iota
+	RoundUp
+	RoundDown
+)
+
+var roundingModes = map[string]RoundingMode{
+	"nearest": RoundNearest,
+	"up":      RoundUp,
+	"down":    RoundDown,
+}
+
+// UnmarshalTOML takes a raw TOML rounding mode value and attempts to parse the value as a
+// RoundingMode. The value passed to this method should be a byte array of a quoted string (i.e.
+// the raw TOML value), the method will remove the quotes.
+func (m *RoundingMode) UnmarshalTOML(bytes []byte) error {
+	text, err := strconv.Unquote(string(bytes))
+	if err != nil {
+		return err
+	}
+
+	return m.Set(text)
+}
+
+// Set parses the given string as a RoundingMode and sets it on this RoundingMode. This allows a
+// RoundingMode to be used as a console parameter value.
+func (m *RoundingMode) Set(text string) error {
 	text = strings.ToLower(text)
 
-	format, ok := formats[text]
+	mode, ok := roundingModes[text]
 	if !ok {
-		return fmt.Errorf("xtime: Invalid DurationFormat '%s'", text)
+		return fmt.Errorf("xtime: Invalid RoundingMode '%s'", text)
 	}
 
-	*f = format
+	*m = mode
 
 	return nil
 }
 
+// String returns the name of this RoundingMode.
+func (m RoundingMode) String() string {
+	for name, mode := range roundingModes {
+		if mode == m {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// RoundingScope is an "enum" of the different places rounding can be applied to in output, i.e.
+// to each individual entry, or only to totals.
+type RoundingScope int
+
+// All possible rounding scopes.
+const (
+	RoundEntry RoundingScope = iota
+	RoundTotal
+)
+
+var roundingScopes = map[string]RoundingScope{
+	"entry": RoundEntry,
+	"total": RoundTotal,
+}
+
+// UnmarshalTOML takes a raw TOML rounding scope value and attempts to parse the value as a
+// RoundingScope. The value passed to this method should be a byte array of a quoted string (i.e.
+// the raw TOML value), the method will remove the quotes.
+func (s *RoundingScope) UnmarshalTOML(bytes []byte) error {
+	text, err := strconv.Unquote(string(bytes))
+	if err != nil {
+		return err
+	}
+
+	return s.Set(text)
+}
+
+// Set parses the given string as a RoundingScope and sets it on this RoundingScope. This allows a
+// RoundingScope to be used as a console parameter value.
+func (s *RoundingScope) Set(text string) error {
+	text = strings.ToLower(text)
+
+	scope, ok := roundingScopes[text]
+	if !ok {
+		return fmt.Errorf("xtime: Invalid RoundingScope '%s'", text)
+	}
+
+	*s = scope
+
+	return nil
+}
+
+// String returns the name of this RoundingScope.
+func (s RoundingScope) String() string {
+	for name, scope := range roundingScopes {
+		if scope == s {
+			return name
+		}
+	}
+
+	return ""
+}
+
 // Weekday is a type used to extend the built-in 'time.Weekday' type to add new methods to help with
 // things like parsing weekday strings into a stricter type.
 type Weekday time.Weekday
@@ -112,9 +282,10 @@ func Date(datetime time.Time) time.Time {
 	return date
 }
 
-// LastWeekday finds the date of the most recent occurrence of a given weekday in the past.
-func LastWeekday(weekday time.Weekday) time.Time {
-	date := Date(time.Now())
+// LastWeekday finds the date of the most recent occurrence of a given weekday, on or before the given
+// current time.
+func LastWeekday(now time.Time, weekday time.Weekday) time.Time {
+	date := Date(now)
 
 	for date.Weekday() != weekday {
 		date = date.AddDate(0, 0, -1)
@@ -123,14 +294,98 @@ func LastWeekday(weekday time.Weekday) time.Time {
 	return date
 }
 
+// RoundDuration rounds the given time.Duration to a multiple of the given increment, using the
+// given RoundingMode. If the increment is not positive, the duration is returned as-is.
+func RoundDuration(duration time.Duration, increment time.Duration, mode RoundingMode) time.Duration {
+	if increment <= 0 {
+		return duration
+	}
+
+	switch mode {
+	case RoundUp:
+		rounded := duration.Truncate(increment)
+		if rounded < duration {
+			rounded = rounded + increment
+		}
+
+		return rounded
+	case RoundDown:
+		return duration.Truncate(increment)
+	case RoundNearest:
+		fallthrough
+	default:
+		return duration.Round(increment)
+	}
+}
+
 // FormatDuration returns the given time.Duration as a string in the given DurationFormat.
 func FormatDuration(duration time.Duration, timeFormat DurationFormat) string {
+	// Durations are stored more precisely than anyone wants to read them.
+	duration = duration.Truncate(time.Second)
+
 	switch timeFormat {
 	case FormatDecimal:
 		return strconv.FormatFloat(duration.Hours(), 'f', 2, 64)
+	case FormatClock:
+		hours, minutes, _ := splitDuration(duration)
+		return fmt.Sprintf("%s%d:%02d", durationSign(duration), hours, minutes)
+	case FormatClockSeconds:
+		hours, minutes, seconds := splitDuration(duration)
+		return fmt.Sprintf("%s%d:%02d:%02d", durationSign(duration), hours, minutes, seconds)
+	case FormatMinutes:
+		return strconv.FormatInt(int64(duration/time.Minute), 10)
+	case FormatISO8601:
+		return formatISO8601(duration)
 	case FormatText:
 		fallthrough
 	default:
 		return duration.String()
 	}
 }
+
+// formatISO8601 returns the given time.Duration as an ISO 8601 duration string (e.g. PT2H34M).
+// Zero-valued components are omitted.
+func formatISO8601(duration time.Duration) string {
+	hours, minutes, seconds := splitDuration(duration)
+
+	if hours == 0 && minutes == 0 && seconds == 0 {
+		return "PT0S"
+	}
+
+	result := durationSign(duration) + "PT"
+
+	if hours > 0 {
+		result = fmt.Sprintf("%s%dH", result, hours)
+	}
+
+	if minutes > 0 {
+		result = fmt.Sprintf("%s%dM", result, minutes)
+	}
+
+	if seconds > 0 {
+		result = fmt.Sprintf("%s%dS", result, seconds)
+	}
+
+	return result
+}
+
+// splitDuration splits the absolute value of the given time.Duration into whole hours, minutes and
+// seconds.
+func splitDuration(duration time.Duration) (int64, int64, int64) {
+	if duration < 0 {
+		duration = -duration
+	}
+
+	seconds := int64(duration / time.Second)
+
+	return seconds / 3600, (seconds / 60) % 60, seconds % 60
+}
+
+// durationSign returns a minus sign if the given time.Duration is negative.
+func durationSign(duration time.Duration) string {
+	if duration < 0 {
+		return "-"
+	}
+
+	return ""
+}
@@ -0,0 +1,117 @@
+package xtime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoundDuration(t *testing.T) {
+	tests := []struct {
+		duration  time.Duration
+		increment time.Duration
+		mode      RoundingMode
+		expected  time.Duration
+	}{
+		{52 * time.Minute, 15 * time.Minute, RoundNearest, 45 * time.Minute},
+		{53 * time.Minute, 15 * time.Minute, RoundNearest, time.Hour},
+		{7*time.Minute + 30*time.Second, 15 * time.Minute, RoundNearest, 15 * time.Minute},
+		{46 * time.Minute, 15 * time.Minute, RoundUp, time.Hour},
+		{45 * time.Minute, 15 * time.Minute, RoundUp, 45 * time.Minute},
+		{time.Second, 6 * time.Minute, RoundUp, 6 * time.Minute},
+		{59 * time.Minute, 15 * time.Minute, RoundDown, 45 * time.Minute},
+		{14 * time.Minute, 15 * time.Minute, RoundDown, 0},
+		{0, 15 * time.Minute, RoundUp, 0},
+		{52 * time.Minute, 0, RoundUp, 52 * time.Minute},
+		{52 * time.Minute, -time.Minute, RoundDown, 52 * time.Minute},
+	}
+
+	for _, test := range tests {
+		actual := RoundDuration(test.duration, test.increment, test.mode)
+		if actual != test.expected {
+			t.Errorf("expected %s rounded %s to %s to be %s, got %s", test.duration, test.mode, test.increment, test.expected, actual)
+		}
+	}
+}
+
+func TestRoundingModeSet(t *testing.T) {
+	var mode RoundingMode
+
+	if err := mode.Set("UP"); err != nil || mode != RoundUp {
+		t.Errorf("expected UP to be parsed as up, got %s, %v", mode, err)
+	}
+
+	if err := mode.Set("sideways"); err == nil {
+		t.Error("expected an error parsing an invalid rounding mode")
+	}
+}
+
+func TestRoundingScopeSet(t *testing.T) {
+	var scope RoundingScope
+
+	if err := scope.Set("Total"); err != nil || scope != RoundTotal {
+		t.Errorf("expected Total to be parsed as total, got %s, %v", scope, err)
+	}
+
+	if err := scope.Set("week"); err == nil {
+		t.Error("expected an error parsing an invalid rounding scope")
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	duration := 2*time.Hour + 34*time.Minute + 37*time.Second + 500*time.Millisecond
+
+	tests := []struct {
+		duration time.Duration
+		format   DurationFormat
+		expected string
+	}{
+		{duration, FormatText, "2h34m37s"},
+		{duration, FormatDecimal, "2.58"},
+		{duration, FormatClock, "2:34"},
+		{duration, FormatClockSeconds, "2:34:37"},
+		{duration, FormatMinutes, "154"},
+		{duration, FormatISO8601, "PT2H34M37S"},
+		{-duration, FormatClock, "-2:34"},
+		{-duration, FormatClockSeconds, "-2:34:37"},
+		{-duration, FormatMinutes, "-154"},
+		{-duration, FormatISO8601, "-PT2H34M37S"},
+		{0, FormatText, "0s"},
+		{0, FormatClock, "0:00"},
+		{0, FormatClockSeconds, "0:00:00"},
+		{0, FormatMinutes, "0"},
+		{0, FormatISO8601, "PT0S"},
+		{500 * time.Millisecond, FormatISO8601, "PT0S"},
+		{-500 * time.Millisecond, FormatISO8601, "PT0S"},
+		{2 * time.Hour, FormatISO8601, "PT2H"},
+		{time.Hour + 5*time.Second, FormatISO8601, "PT1H5S"},
+		{26 * time.Hour, FormatClock, "26:00"},
+	}
+
+	for _, test := range tests {
+		actual := FormatDuration(test.duration, test.format)
+		if actual != test.expected {
+			t.Errorf("expected %s formatted as %s to be %q, got %q", test.duration, test.format, test.expected, actual)
+		}
+	}
+}
+
+func TestParseDurationFormat(t *testing.T) {
+	for name, expected := range formats {
+		format, err := ParseDurationFormat(name)
+		if err != nil || format != expected {
+			t.Errorf("expected %s to be parsed, got %d, %v", name, format, err)
+		}
+
+		if format.String() != name {
+			t.Errorf("expected %s to be the name of format %d, got %s", name, format, format.String())
+		}
+	}
+
+	if format, err := ParseDurationFormat("ISO8601"); err != nil || format != FormatISO8601 {
+		t.Errorf("expected formats to be parsed regardless of case, got %s, %v", format, err)
+	}
+
+	if _, err := ParseDurationFormat("fortnights"); err == nil {
+		t.Error("expected an error parsing an invalid format")
+	}
+}
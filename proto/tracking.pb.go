@@ -12,9 +12,16 @@ It has these top-level messages:
 	SysMigrationsStatus
 	SysTrackingStatus
 	SysWorkspaceIndex
+	SysCalendar
+	SysCalendarDay
+	SysJournal
+	SysJournalOperation
+	SysJournalChange
 	TrackingTimesheet
 	TrackingEntry
 	TrackingEntryRef
+	TrackingEntryLog
+	TrackingEntryRevision
 */
 package proto
 
@@ -114,6 +121,187 @@ func (m *SysWorkspaceIndex) GetWorkspaces() []string {
 	return nil
 }
 
+// SysCalendar keeps track of non-working days, e.g. holidays and leave.
+type SysCalendar struct {
+	// The non-working days in the calendar.
+	Days []*SysCalendarDay `protobuf:"bytes,1,rep,name=days" json:"days,omitempty"`
+}
+
+func (m *SysCalendar) Reset()                    { *m = SysCalendar{} }
+func (m *SysCalendar) String() string            { return proto1.CompactTextString(m) }
+func (*SysCalendar) ProtoMessage()               {}
+func (*SysCalendar) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *SysCalendar) GetDays() []*SysCalendarDay {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+// SysCalendarDay represents a single non-working day.
+type SysCalendarDay struct {
+	// The date of the day, in the same format as timesheet keys.
+	Date string `protobuf:"bytes,1,opt,name=date" json:"date,omitempty"`
+	// The reason this day is a non-working day.
+	Reason string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *SysCalendarDay) Reset()                    { *m = SysCalendarDay{} }
+func (m *SysCalendarDay) String() string            { return proto1.CompactTextString(m) }
+func (*SysCalendarDay) ProtoMessage()               {}
+func (*SysCalendarDay) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *SysCalendarDay) GetDate() string {
+	if m != nil {
+		return m.Date
+	}
+	return ""
+}
+
+func (m *SysCalendarDay) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// SysJournal keeps track of the operations that have changed data, so that they can be undone and
+// redone.
+type SysJournal struct {
+	// The keys of the operations in the journal, oldest first.
+	Operations []string `protobuf:"bytes,1,rep,name=operations" json:"operations,omitempty"`
+	// The number of operations in the journal that have not been undone.
+	Position uint32 `protobuf:"varint,2,opt,name=position" json:"position,omitempty"`
+}
+
+func (m *SysJournal) Reset()                    { *m = SysJournal{} }
+func (m *SysJournal) String() string            { return proto1.CompactTextString(m) }
+func (*SysJournal) ProtoMessage()               {}
+func (*SysJournal) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *SysJournal) GetOperations() []string {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
+}
+
+func (m *SysJournal) GetPosition() uint32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+// SysJournalOperation represents a single operation that changed data.
+type SysJournalOperation struct {
+	// The key of this operation.
+	Key string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	// A description of what this operation did.
+	Description string `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	// The unix timestamp, in nanoseconds, of when this operation happened.
+	CreatedNanos int64 `protobuf:"varint,3,opt,name=created_nanos,json=createdNanos" json:"created_nanos,omitempty"`
+	// The changes this operation made, in the order they were first made.
+	Changes []*SysJournalChange `protobuf:"bytes,4,rep,name=changes" json:"changes,omitempty"`
+}
+
+func (m *SysJournalOperation) Reset()                    { *m = SysJournalOperation{} }
+func (m *SysJournalOperation) String() string            { return proto1.CompactTextString(m) }
+func (*SysJournalOperation) ProtoMessage()               {}
+func (*SysJournalOperation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *SysJournalOperation) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SysJournalOperation) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *SysJournalOperation) GetCreatedNanos() int64 {
+	if m != nil {
+		return m.CreatedNanos
+	}
+	return 0
+}
+
+func (m *SysJournalOperation) GetChanges() []*SysJournalChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+// SysJournalChange represents a change made to a single key, or to a bucket if no key is set.
+type SysJournalChange struct {
+	// The bucket that was changed.
+	Bucket string `protobuf:"bytes,1,opt,name=bucket" json:"bucket,omitempty"`
+	// The key that was changed.
+	Key string `protobuf:"bytes,2,opt,name=key" json:"key,omitempty"`
+	// The value before the change.
+	Before []byte `protobuf:"bytes,3,opt,name=before,proto3" json:"before,omitempty"`
+	// The value after the change.
+	After []byte `protobuf:"bytes,4,opt,name=after,proto3" json:"after,omitempty"`
+	// Whether the key or bucket was created by the change, i.e. there is no value before it.
+	Created bool `protobuf:"varint,5,opt,name=created" json:"created,omitempty"`
+	// Whether the key or bucket was deleted by the change, i.e. there is no value after it.
+	Deleted bool `protobuf:"varint,6,opt,name=deleted" json:"deleted,omitempty"`
+}
+
+func (m *SysJournalChange) Reset()                    { *m = SysJournalChange{} }
+func (m *SysJournalChange) String() string            { return proto1.CompactTextString(m) }
+func (*SysJournalChange) ProtoMessage()               {}
+func (*SysJournalChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *SysJournalChange) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *SysJournalChange) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SysJournalChange) GetBefore() []byte {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *SysJournalChange) GetAfter() []byte {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func (m *SysJournalChange) GetCreated() bool {
+	if m != nil {
+		return m.Created
+	}
+	return false
+}
+
+func (m *SysJournalChange) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
 // TrackingTimesheet represents a timesheet. It contains all of the entries for a time period.
 type TrackingTimesheet struct {
 	// The key of this timesheet.
@@ -121,12 +309,16 @@ type TrackingTimesheet struct {
 	// A timesheet consists of many entries. A timesheet can be totally empty, it's just there to
 	// hold the reference to a bunch of entries under a date, i.e. for easy access.
 	Entries []string `protobuf:"bytes,2,rep,name=entries" json:"entries,omitempty"`
+	// The name of the time zone this timesheet was created in.
+	Zone string `protobuf:"bytes,3,opt,name=zone" json:"zone,omitempty"`
+	// The offset from UTC, in seconds, of the time zone this timesheet was created in.
+	ZoneOffset int32 `protobuf:"varint,4,opt,name=zone_offset,json=zoneOffset" json:"zone_offset,omitempty"`
 }
 
 func (m *TrackingTimesheet) Reset()                    { *m = TrackingTimesheet{} }
 func (m *TrackingTimesheet) String() string            { return proto1.CompactTextString(m) }
 func (*TrackingTimesheet) ProtoMessage()               {}
-func (*TrackingTimesheet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*TrackingTimesheet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *TrackingTimesheet) GetKey() string {
 	if m != nil {
@@ -142,6 +334,20 @@ func (m *TrackingTimesheet) GetEntries() []string {
 	return nil
 }
 
+func (m *TrackingTimesheet) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
+func (m *TrackingTimesheet) GetZoneOffset() int32 {
+	if m != nil {
+		return m.ZoneOffset
+	}
+	return 0
+}
+
 // TrackingEntry represents an entry in the timesheet. This will have enough information to commit
 // to the duration, and also enough information to identify the entry.
 type TrackingEntry struct {
@@ -151,18 +357,46 @@ type TrackingEntry struct {
 	Timesheet string `protobuf:"bytes,2,opt,name=timesheet" json:"timesheet,omitempty"`
 	// The note associated with this entry.
 	Note string `protobuf:"bytes,3,opt,name=note" json:"note,omitempty"`
-	// The unix timestamp of when this entry was created.
+	// Deprecated: The unix timestamp of when this entry was created. Replaced by created_nanos.
 	Created uint64 `protobuf:"varint,4,opt,name=created" json:"created,omitempty"`
-	// The unix timestamp of when this entry was last updated.
+	// Deprecated: The unix timestamp of when this entry was last updated. Replaced by updated_nanos.
 	Updated uint64 `protobuf:"varint,5,opt,name=updated" json:"updated,omitempty"`
-	// The number of seconds this has been tracked for (once committed).
+	// Deprecated: The number of seconds this has been tracked for. Replaced by duration_nanos.
 	Duration uint64 `protobuf:"varint,6,opt,name=duration" json:"duration,omitempty"`
+	// Deprecated: The number of seconds this entry was planned to be tracked for. Replaced by
+	// planned_nanos.
+	Planned uint64 `protobuf:"varint,7,opt,name=planned" json:"planned,omitempty"`
+	// Deprecated: The number of seconds of break to record once the planned duration has been
+	// reached. Replaced by planned_break_nanos.
+	PlannedBreak uint64 `protobuf:"varint,8,opt,name=planned_break,json=plannedBreak" json:"planned_break,omitempty"`
+	// The key of the entry this entry continues from a previous day, if it was split at the end of
+	// that day.
+	Continues string `protobuf:"bytes,9,opt,name=continues" json:"continues,omitempty"`
+	// The name of the time zone this entry was created in.
+	Zone string `protobuf:"bytes,10,opt,name=zone" json:"zone,omitempty"`
+	// The offset from UTC, in seconds, of the time zone this entry was created in.
+	ZoneOffset int32 `protobuf:"varint,11,opt,name=zone_offset,json=zoneOffset" json:"zone_offset,omitempty"`
+	// The unix timestamp, in nanoseconds, of when this entry was created.
+	CreatedNanos int64 `protobuf:"varint,12,opt,name=created_nanos,json=createdNanos" json:"created_nanos,omitempty"`
+	// The unix timestamp, in nanoseconds, of when this entry was last updated.
+	UpdatedNanos int64 `protobuf:"varint,13,opt,name=updated_nanos,json=updatedNanos" json:"updated_nanos,omitempty"`
+	// The number of nanoseconds this has been tracked for (once committed).
+	DurationNanos int64 `protobuf:"varint,14,opt,name=duration_nanos,json=durationNanos" json:"duration_nanos,omitempty"`
+	// The number of nanoseconds this entry was planned to be tracked for, or 0 if it's not
+	// timeboxed.
+	PlannedNanos int64 `protobuf:"varint,15,opt,name=planned_nanos,json=plannedNanos" json:"planned_nanos,omitempty"`
+	// The number of nanoseconds of break to record once the planned duration has been reached.
+	PlannedBreakNanos int64 `protobuf:"varint,16,opt,name=planned_break_nanos,json=plannedBreakNanos" json:"planned_break_nanos,omitempty"`
+	// The unix timestamp, in nanoseconds, of when this entry's timer was last started.
+	StartedNanos int64 `protobuf:"varint,17,opt,name=started_nanos,json=startedNanos" json:"started_nanos,omitempty"`
+	// The number of nanoseconds this had been tracked for when it's timer was last started.
+	StartedDurationNanos int64 `protobuf:"varint,18,opt,name=started_duration_nanos,json=startedDurationNanos" json:"started_duration_nanos,omitempty"`
 }
 
 func (m *TrackingEntry) Reset()                    { *m = TrackingEntry{} }
 func (m *TrackingEntry) String() string            { return proto1.CompactTextString(m) }
 func (*TrackingEntry) ProtoMessage()               {}
-func (*TrackingEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*TrackingEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *TrackingEntry) GetKey() string {
 	if m != nil {
@@ -206,6 +440,90 @@ func (m *TrackingEntry) GetDuration() uint64 {
 	return 0
 }
 
+func (m *TrackingEntry) GetPlanned() uint64 {
+	if m != nil {
+		return m.Planned
+	}
+	return 0
+}
+
+func (m *TrackingEntry) GetPlannedBreak() uint64 {
+	if m != nil {
+		return m.PlannedBreak
+	}
+	return 0
+}
+
+func (m *TrackingEntry) GetContinues() string {
+	if m != nil {
+		return m.Continues
+	}
+	return ""
+}
+
+func (m *TrackingEntry) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
+func (m *TrackingEntry) GetZoneOffset() int32 {
+	if m != nil {
+		return m.ZoneOffset
+	}
+	return 0
+}
+
+func (m *TrackingEntry) GetCreatedNanos() int64 {
+	if m != nil {
+		return m.CreatedNanos
+	}
+	return 0
+}
+
+func (m *TrackingEntry) GetUpdatedNanos() int64 {
+	if m != nil {
+		return m.UpdatedNanos
+	}
+	return 0
+}
+
+func (m *TrackingEntry) GetDurationNanos() int64 {
+	if m != nil {
+		return m.DurationNanos
+	}
+	return 0
+}
+
+func (m *TrackingEntry) GetPlannedNanos() int64 {
+	if m != nil {
+		return m.PlannedNanos
+	}
+	return 0
+}
+
+func (m *TrackingEntry) GetPlannedBreakNanos() int64 {
+	if m != nil {
+		return m.PlannedBreakNanos
+	}
+	return 0
+}
+
+func (m *TrackingEntry) GetStartedNanos() int64 {
+	if m != nil {
+		return m.StartedNanos
+	}
+	return 0
+}
+
+func (m *TrackingEntry) GetStartedDurationNanos() int64 {
+	if m != nil {
+		return m.StartedDurationNanos
+	}
+	return 0
+}
+
 // TrackingEntryRef represents a reference from an entry's short key to it's full key.
 type TrackingEntryRef struct {
 	// The key of this entry reference.
@@ -217,7 +535,7 @@ type TrackingEntryRef struct {
 func (m *TrackingEntryRef) Reset()                    { *m = TrackingEntryRef{} }
 func (m *TrackingEntryRef) String() string            { return proto1.CompactTextString(m) }
 func (*TrackingEntryRef) ProtoMessage()               {}
-func (*TrackingEntryRef) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*TrackingEntryRef) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *TrackingEntryRef) GetKey() string {
 	if m != nil {
@@ -233,37 +551,174 @@ func (m *TrackingEntryRef) GetEntry() string {
 	return ""
 }
 
+// TrackingEntryLog represents the history of changes made to an entry. Revisions are only ever
+// appended to it.
+type TrackingEntryLog struct {
+	// The key of the entry this log belongs to.
+	Entry string `protobuf:"bytes,1,opt,name=entry" json:"entry,omitempty"`
+	// The revisions made to the entry, oldest first.
+	Revisions []*TrackingEntryRevision `protobuf:"bytes,2,rep,name=revisions" json:"revisions,omitempty"`
+}
+
+func (m *TrackingEntryLog) Reset()                    { *m = TrackingEntryLog{} }
+func (m *TrackingEntryLog) String() string            { return proto1.CompactTextString(m) }
+func (*TrackingEntryLog) ProtoMessage()               {}
+func (*TrackingEntryLog) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *TrackingEntryLog) GetEntry() string {
+	if m != nil {
+		return m.Entry
+	}
+	return ""
+}
+
+func (m *TrackingEntryLog) GetRevisions() []*TrackingEntryRevision {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+// TrackingEntryRevision represents a single change made to an entry.
+type TrackingEntryRevision struct {
+	// The unix timestamp, in nanoseconds, of when this change was made.
+	CreatedNanos int64 `protobuf:"varint,1,opt,name=created_nanos,json=createdNanos" json:"created_nanos,omitempty"`
+	// The name of the user who made this change.
+	Author string `protobuf:"bytes,2,opt,name=author" json:"author,omitempty"`
+	// What was done to the entry, e.g. "start", or "update duration".
+	Action string `protobuf:"bytes,3,opt,name=action" json:"action,omitempty"`
+	// The note of the entry before this change.
+	PreviousNote string `protobuf:"bytes,4,opt,name=previous_note,json=previousNote" json:"previous_note,omitempty"`
+	// The note of the entry after this change.
+	Note string `protobuf:"bytes,5,opt,name=note" json:"note,omitempty"`
+	// The number of nanoseconds the entry had been tracked for before this change.
+	PreviousDurationNanos int64 `protobuf:"varint,6,opt,name=previous_duration_nanos,json=previousDurationNanos" json:"previous_duration_nanos,omitempty"`
+	// The number of nanoseconds the entry had been tracked for after this change.
+	DurationNanos int64 `protobuf:"varint,7,opt,name=duration_nanos,json=durationNanos" json:"duration_nanos,omitempty"`
+}
+
+func (m *TrackingEntryRevision) Reset()                    { *m = TrackingEntryRevision{} }
+func (m *TrackingEntryRevision) String() string            { return proto1.CompactTextString(m) }
+func (*TrackingEntryRevision) ProtoMessage()               {}
+func (*TrackingEntryRevision) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *TrackingEntryRevision) GetCreatedNanos() int64 {
+	if m != nil {
+		return m.CreatedNanos
+	}
+	return 0
+}
+
+func (m *TrackingEntryRevision) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *TrackingEntryRevision) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *TrackingEntryRevision) GetPreviousNote() string {
+	if m != nil {
+		return m.PreviousNote
+	}
+	return ""
+}
+
+func (m *TrackingEntryRevision) GetNote() string {
+	if m != nil {
+		return m.Note
+	}
+	return ""
+}
+
+func (m *TrackingEntryRevision) GetPreviousDurationNanos() int64 {
+	if m != nil {
+		return m.PreviousDurationNanos
+	}
+	return 0
+}
+
+func (m *TrackingEntryRevision) GetDurationNanos() int64 {
+	if m != nil {
+		return m.DurationNanos
+	}
+	return 0
+}
+
 func init() {
 	proto1.RegisterType((*SysMigrationsStatus)(nil), "proto.SysMigrationsStatus")
 	proto1.RegisterType((*SysTrackingStatus)(nil), "proto.SysTrackingStatus")
 	proto1.RegisterType((*SysWorkspaceIndex)(nil), "proto.SysWorkspaceIndex")
+	proto1.RegisterType((*SysCalendar)(nil), "proto.SysCalendar")
+	proto1.RegisterType((*SysCalendarDay)(nil), "proto.SysCalendarDay")
+	proto1.RegisterType((*SysJournal)(nil), "proto.SysJournal")
+	proto1.RegisterType((*SysJournalOperation)(nil), "proto.SysJournalOperation")
+	proto1.RegisterType((*SysJournalChange)(nil), "proto.SysJournalChange")
 	proto1.RegisterType((*TrackingTimesheet)(nil), "proto.TrackingTimesheet")
 	proto1.RegisterType((*TrackingEntry)(nil), "proto.TrackingEntry")
 	proto1.RegisterType((*TrackingEntryRef)(nil), "proto.TrackingEntryRef")
+	proto1.RegisterType((*TrackingEntryLog)(nil), "proto.TrackingEntryLog")
+	proto1.RegisterType((*TrackingEntryRevision)(nil), "proto.TrackingEntryRevision")
 }
 
 func init() { proto1.RegisterFile("tracking.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 312 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x31, 0x4f, 0xfb, 0x30,
-	0x10, 0xc5, 0xe5, 0x36, 0xed, 0xbf, 0x39, 0xe9, 0x8f, 0x5a, 0xc3, 0x60, 0x21, 0x40, 0x95, 0xa7,
-	0x4e, 0x48, 0xa8, 0x1b, 0x0b, 0x13, 0x03, 0x03, 0x8b, 0x53, 0x89, 0x11, 0x85, 0xe4, 0x08, 0x56,
-	0x84, 0x13, 0xd9, 0x0e, 0x90, 0x9d, 0x6f, 0xc2, 0x17, 0x45, 0xb6, 0x13, 0x53, 0x04, 0x62, 0x8a,
-	0xdf, 0x7b, 0xb9, 0xf3, 0xef, 0x6c, 0xc3, 0x81, 0xd5, 0x79, 0x51, 0x4b, 0x55, 0x9d, 0xb7, 0xba,
-	0xb1, 0x0d, 0x9d, 0xf9, 0x0f, 0xbf, 0x80, 0xc3, 0xac, 0x37, 0xb7, 0xb2, 0xd2, 0xb9, 0x95, 0x8d,
-	0x32, 0x99, 0xcd, 0x6d, 0x67, 0xe8, 0x31, 0x2c, 0x5e, 0x50, 0x1b, 0xe7, 0x30, 0xb2, 0x9e, 0x6e,
-	0x12, 0x11, 0x35, 0x7f, 0x27, 0xb0, 0xca, 0x7a, 0xb3, 0x1b, 0xfa, 0x0d, 0x15, 0xa7, 0x00, 0xd2,
-	0xdc, 0xeb, 0x4e, 0x29, 0xa9, 0x2a, 0x46, 0xd6, 0x64, 0xb3, 0x10, 0xa9, 0x34, 0x22, 0x18, 0xf4,
-	0x04, 0x52, 0x2b, 0x9f, 0xd1, 0x3c, 0x21, 0x5a, 0x36, 0x59, 0x93, 0x4d, 0x2a, 0xbe, 0x0c, 0x7a,
-	0x04, 0x33, 0x54, 0x56, 0xf7, 0x6c, 0xea, 0x93, 0x20, 0x5c, 0xcd, 0x6b, 0xa3, 0x6b, 0xd3, 0xe6,
-	0x05, 0xb2, 0x24, 0xd4, 0x44, 0x83, 0x6f, 0x3d, 0xc5, 0xdd, 0xa8, 0x6f, 0x54, 0x89, 0x6f, 0xf4,
-	0x0c, 0x20, 0xfe, 0x11, 0xc8, 0x53, 0xb1, 0xe7, 0xf0, 0x2b, 0x58, 0x8d, 0xdc, 0xbb, 0xb8, 0xfb,
-	0x12, 0xa6, 0x35, 0xf6, 0x9e, 0x39, 0x15, 0x6e, 0x49, 0x19, 0xfc, 0x73, 0x08, 0x12, 0x0d, 0x9b,
-	0xf8, 0x1e, 0xa3, 0xe4, 0x1f, 0x04, 0xfe, 0x8f, 0x1d, 0xae, 0x3d, 0xe5, 0xcf, 0xea, 0xbf, 0x67,
-	0xa5, 0x90, 0xa8, 0xc6, 0xe2, 0x30, 0xaa, 0x5f, 0xbb, 0xfd, 0x0a, 0x8d, 0xb9, 0xc5, 0xd2, 0xcf,
-	0x99, 0x88, 0x51, 0xba, 0xa4, 0x6b, 0x4b, 0x9f, 0xcc, 0x42, 0x32, 0x48, 0x77, 0x45, 0x65, 0x17,
-	0x6e, 0x8d, 0xcd, 0x7d, 0x14, 0x35, 0xbf, 0x84, 0xe5, 0x37, 0x48, 0x81, 0x8f, 0xbf, 0x70, 0xc6,
-	0x53, 0x9f, 0xec, 0x9d, 0xfa, 0xc3, 0xdc, 0x3f, 0x8c, 0xed, 0x67, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0xe2, 0x94, 0x67, 0x78, 0x31, 0x02, 0x00, 0x00,
+	// 792 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0x5d, 0x6f, 0xf3, 0x34,
+	0x14, 0x56, 0xda, 0xb4, 0x5d, 0x4e, 0x3f, 0x68, 0xbd, 0x77, 0x7b, 0x23, 0xf4, 0x02, 0x55, 0x26,
+	0xa4, 0x71, 0x33, 0x69, 0x0c, 0x21, 0x34, 0x71, 0xc5, 0x86, 0x04, 0x08, 0x36, 0xc9, 0x9d, 0xc4,
+	0x65, 0xe5, 0x25, 0x6e, 0x17, 0xb5, 0xd8, 0x91, 0xed, 0x0c, 0xc2, 0xf5, 0x7e, 0x07, 0x12, 0x7f,
+	0x8a, 0xdf, 0x83, 0xfc, 0x95, 0xa4, 0x1f, 0x70, 0x15, 0x9f, 0xe7, 0x3c, 0xe7, 0xf8, 0x9c, 0xe3,
+	0xc7, 0x0e, 0x4c, 0x94, 0x20, 0xe9, 0x26, 0x67, 0xeb, 0xab, 0x42, 0x70, 0xc5, 0x51, 0xcf, 0x7c,
+	0x92, 0x6b, 0x38, 0x5d, 0x54, 0xf2, 0x97, 0x7c, 0x2d, 0x88, 0xca, 0x39, 0x93, 0x0b, 0x45, 0x54,
+	0x29, 0xd1, 0xc7, 0x70, 0xf2, 0x4a, 0x85, 0xd4, 0x48, 0x1c, 0xcc, 0xbb, 0x97, 0x21, 0xae, 0xed,
+	0xe4, 0x2d, 0x80, 0xd9, 0xa2, 0x92, 0x4f, 0x2e, 0x9f, 0x8b, 0xf8, 0x04, 0x20, 0x97, 0x4b, 0x51,
+	0x32, 0x96, 0xb3, 0x75, 0x1c, 0xcc, 0x83, 0xcb, 0x13, 0x1c, 0xe5, 0x12, 0x5b, 0x00, 0x7d, 0x80,
+	0x48, 0xe5, 0xbf, 0x51, 0xf9, 0x42, 0xa9, 0x8a, 0x3b, 0xf3, 0xe0, 0x32, 0xc2, 0x0d, 0x80, 0xde,
+	0x41, 0x8f, 0x32, 0x25, 0xaa, 0xb8, 0x6b, 0x3c, 0xd6, 0xd0, 0x31, 0xbf, 0x73, 0xb1, 0x91, 0x05,
+	0x49, 0x69, 0x1c, 0xda, 0x98, 0x1a, 0x48, 0x6e, 0x4c, 0x15, 0xbf, 0x7a, 0xfb, 0x47, 0x96, 0xd1,
+	0x3f, 0xd0, 0xa7, 0x00, 0x35, 0xc3, 0x56, 0x1e, 0xe1, 0x16, 0x92, 0x7c, 0x03, 0xc3, 0x45, 0x25,
+	0xef, 0xc8, 0x96, 0xb2, 0x8c, 0x08, 0xf4, 0x05, 0x84, 0x19, 0xa9, 0x2c, 0x71, 0xf8, 0xe5, 0x99,
+	0x1d, 0xcd, 0x55, 0x8b, 0x71, 0x4f, 0x2a, 0x6c, 0x28, 0xc9, 0xb7, 0x30, 0xd9, 0xc5, 0x11, 0xd2,
+	0xc1, 0x8a, 0x9a, 0x5e, 0x23, 0x6c, 0xd6, 0xe8, 0x1c, 0xfa, 0x82, 0x12, 0xc9, 0x99, 0xeb, 0xd1,
+	0x59, 0xc9, 0x0f, 0x00, 0x8b, 0x4a, 0xfe, 0xc4, 0x4b, 0xc1, 0xc8, 0x56, 0x57, 0xc9, 0x0b, 0xea,
+	0x26, 0xee, 0xab, 0x6c, 0x10, 0x3d, 0xfd, 0x82, 0xcb, 0x5c, 0xe5, 0x2e, 0xcf, 0x18, 0xd7, 0x76,
+	0xf2, 0x77, 0x00, 0xa7, 0x4d, 0xaa, 0x47, 0x1f, 0x84, 0xa6, 0xd0, 0xdd, 0xd0, 0xca, 0x15, 0xa3,
+	0x97, 0x68, 0x0e, 0xc3, 0x8c, 0xca, 0x54, 0xe4, 0x45, 0x9d, 0x28, 0xc2, 0x6d, 0x08, 0x5d, 0xc0,
+	0x38, 0x15, 0x94, 0x28, 0x9a, 0x2d, 0x19, 0x61, 0x5c, 0x9a, 0xf1, 0x77, 0xf1, 0xc8, 0x81, 0x0f,
+	0x1a, 0x43, 0xd7, 0x30, 0x48, 0x5f, 0x08, 0x5b, 0x53, 0x19, 0x87, 0x66, 0x4c, 0xef, 0x9b, 0x31,
+	0xb9, 0x2a, 0xee, 0x8c, 0x1f, 0x7b, 0x5e, 0xf2, 0x57, 0x00, 0xd3, 0x7d, 0xaf, 0x1e, 0xcd, 0x73,
+	0x99, 0x6e, 0xa8, 0x72, 0x35, 0x3a, 0xcb, 0x17, 0xde, 0x69, 0x0a, 0xd7, 0x4c, 0xba, 0xe2, 0x82,
+	0x9a, 0x7a, 0x46, 0xd8, 0x59, 0x5a, 0x25, 0x64, 0xa5, 0xa8, 0x30, 0x5a, 0x18, 0x61, 0x6b, 0xa0,
+	0x18, 0x06, 0xae, 0xde, 0xb8, 0x67, 0x54, 0xe7, 0x4d, 0xed, 0xc9, 0xe8, 0x96, 0x6a, 0x4f, 0xdf,
+	0x7a, 0x9c, 0x99, 0x28, 0x98, 0x79, 0xf9, 0x3e, 0xd5, 0x22, 0x3c, 0x9c, 0x60, 0x0c, 0x03, 0xad,
+	0xc4, 0x9c, 0xca, 0xb8, 0x63, 0x0e, 0xc9, 0x9b, 0xfa, 0xec, 0xff, 0xe4, 0x8c, 0x3a, 0xbd, 0x9a,
+	0x35, 0xfa, 0x0c, 0x86, 0xfa, 0xbb, 0xe4, 0xab, 0x95, 0xa4, 0xca, 0x14, 0xd9, 0xc3, 0xa0, 0xa1,
+	0x47, 0x83, 0x24, 0xff, 0x84, 0x30, 0xf6, 0xdb, 0x7e, 0x6f, 0x14, 0x7e, 0xb8, 0xe5, 0xff, 0xdf,
+	0x13, 0x04, 0x21, 0xe3, 0xaa, 0xde, 0x56, 0xaf, 0xdb, 0xfd, 0xeb, 0x2d, 0xc3, 0x9d, 0xfe, 0xcb,
+	0x22, 0xab, 0x27, 0x13, 0x62, 0x6f, 0x6a, 0x81, 0x65, 0xa5, 0x15, 0x8e, 0x19, 0x4d, 0x88, 0x6b,
+	0x5b, 0x47, 0x15, 0x5b, 0xc2, 0x18, 0xcd, 0xe2, 0x81, 0x8d, 0x72, 0xa6, 0x96, 0x8b, 0x5b, 0x2e,
+	0x9f, 0x05, 0x25, 0x9b, 0xf8, 0xc4, 0xf8, 0x47, 0x0e, 0xfc, 0x4e, 0x63, 0xba, 0x81, 0x94, 0x33,
+	0x95, 0xb3, 0x92, 0xca, 0x38, 0xb2, 0x0d, 0xd4, 0x40, 0x3d, 0x37, 0xf8, 0xef, 0xb9, 0x0d, 0xf7,
+	0xe7, 0x76, 0x28, 0xd3, 0xd1, 0x11, 0x99, 0x5e, 0xc0, 0xd8, 0x75, 0xe7, 0x48, 0x63, 0x4b, 0x72,
+	0xa0, 0x25, 0x7d, 0x0e, 0x13, 0xdf, 0xa7, 0x63, 0x4d, 0x0c, 0x6b, 0xec, 0xd1, 0x3a, 0x97, 0x6f,
+	0xd4, 0xb2, 0x3e, 0xb2, 0xb9, 0x1c, 0x68, 0x49, 0x57, 0x70, 0xba, 0x33, 0x0d, 0x47, 0x9d, 0x1a,
+	0xea, 0xac, 0x3d, 0x93, 0x3a, 0xa9, 0x54, 0x44, 0x34, 0x05, 0xce, 0x6c, 0x52, 0x07, 0x5a, 0xd2,
+	0x57, 0x70, 0xee, 0x49, 0x7b, 0x85, 0x22, 0xc3, 0x7e, 0xe7, 0xbc, 0xf7, 0xed, 0x7a, 0x93, 0x5b,
+	0x98, 0xee, 0xe8, 0x0a, 0xd3, 0xd5, 0x11, 0x69, 0xd5, 0x8f, 0x6c, 0xa7, 0xf5, 0xc8, 0x26, 0xd9,
+	0x5e, 0xec, 0xcf, 0x7c, 0xdd, 0x30, 0x83, 0x16, 0x13, 0xdd, 0x42, 0x24, 0xe8, 0x6b, 0x6e, 0x7f,
+	0x0a, 0x1d, 0xf3, 0x14, 0x7c, 0x70, 0x4f, 0xc1, 0xde, 0xee, 0x96, 0x84, 0x1b, 0x7a, 0xf2, 0xd6,
+	0x81, 0xb3, 0xa3, 0xa4, 0xc3, 0xc3, 0x0d, 0x8e, 0x1c, 0xee, 0x39, 0xf4, 0x49, 0xa9, 0x5e, 0xb8,
+	0xf0, 0xcf, 0xaa, 0xb5, 0x0c, 0x9e, 0x1a, 0x15, 0x77, 0x1d, 0x9e, 0xfa, 0x87, 0xad, 0xd0, 0x9b,
+	0xf3, 0x52, 0x2e, 0xcd, 0x85, 0xb1, 0x7f, 0x8f, 0x91, 0x07, 0x1f, 0xf4, 0xc5, 0xf1, 0x97, 0xa9,
+	0xd7, 0xba, 0x4c, 0x5f, 0xc3, 0xfb, 0x3a, 0x70, 0xef, 0x00, 0xfa, 0xa6, 0xae, 0x33, 0xef, 0xde,
+	0x39, 0x81, 0x23, 0xc2, 0x1a, 0x1c, 0x11, 0xd6, 0x73, 0xdf, 0x8c, 0xeb, 0xe6, 0xdf, 0x01, 0x00,
+	0x9d, 0xec, 0xb7, 0xae, 0x8d, 0x07, 0x00, 0x00,
 }